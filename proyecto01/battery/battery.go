@@ -0,0 +1,15 @@
+// Package battery embeds the maps shipped with the game, they're always
+// available even if the map directory of the user is empty
+package battery
+
+import "embed"
+
+//go:embed *.txt
+var Maps embed.FS
+
+// Regressions are maps that once broke an algorithm, the tests of
+// searchAlgorithms solve them again. The game doesn't list them. The whole
+// directory is embedded so it can be empty
+//
+//go:embed regressions
+var Regressions embed.FS
@@ -0,0 +1,35 @@
+// Package appdir finds the directories where the game keeps its files. Each
+// one can be set with a flag or an environment variable, otherwise they all
+// live under the same directory of the user config, so removing it forgets
+// everything the game saved
+package appdir
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// Root is the directory of the game in the user config
+const Root = "InteligenciaArtificial"
+
+// Dir returns the directory given with a flag, the one in the environment
+// variable env or fallback, in that order
+func Dir(flag, env, fallback string) string {
+	if flag != "" {
+		return flag
+	}
+	if dir := os.Getenv(env); dir != "" {
+		return dir
+	}
+	return fallback
+}
+
+// Config is the directory name under Root in the user config, or name in the
+// working directory when there's no user config
+func Config(name string) string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return name
+	}
+	return filepath.Join(configDir, Root, name)
+}
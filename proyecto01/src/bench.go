@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Krud3/InteligenciaArtificial/src/datatypes"
+	"github.com/Krud3/InteligenciaArtificial/src/i18n"
+	"github.com/Krud3/InteligenciaArtificial/src/searchAlgorithms"
+	"github.com/Krud3/InteligenciaArtificial/src/utils"
+	"github.com/Krud3/InteligenciaArtificial/src/validator"
+)
+
+// Limits of each run of the benchmark when no limit flag is given, so an
+// algorithm lost in a big map doesn't stop the rest
+var benchLimits = searchAlgorithms.Limits{Timeout: 30 * time.Second, MaxExpansions: 5_000_000, MaxMemory: 1 << 30}
+
+// runBenchmark runs every algorithm over every map and checks each solution
+// found. It returns false if any path is invalid or any cost is wrong
+func runBenchmark(w io.Writer, limits searchAlgorithms.Limits) (bool, error) {
+	if limits == (searchAlgorithms.Limits{}) {
+		limits = benchLimits
+	}
+	maps, err := utils.ListMaps()
+	if err != nil {
+		return false, err
+	}
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", i18n.T("bench.map"), i18n.T("bench.algorithm"), i18n.T("bench.status"), i18n.T("bench.cost"), i18n.T("bench.checked"), i18n.T("bench.nodes"), i18n.T("bench.time"), i18n.T("bench.verdict"))
+	runs, mismatches, invalid := 0, 0, 0
+	for _, name := range maps {
+		matrix, err := utils.GetMatrix(name)
+		if err != nil {
+			fmt.Fprintf(table, "%s\t%s\n", name, err)
+			continue
+		}
+		for _, algorithm := range searchAlgorithms.AlgorithmNames {
+			result, err := searchAlgorithms.RunLimited(context.Background(), algorithm, matrix, nil, limits)
+			if err != nil {
+				fmt.Fprintf(table, "%s\t%s\t%s\n", name, i18n.Algorithm(algorithm), err)
+				continue
+			}
+			runs++
+			cost, checked, verdict := "-", "-", "-"
+			if result.SolutionFound {
+				report := validator.Check(matrix, result.PathFound)
+				cost = fmt.Sprintf("%.0f", result.Cost)
+				checked = fmt.Sprintf("%.0f", report.Cost)
+				switch {
+				case !report.Valid():
+					invalid++
+					verdict = i18n.T("bench.invalid", report.Problems[0])
+				case !report.CostMatches(result.Cost):
+					mismatches++
+					verdict = i18n.T("bench.mismatch")
+				default:
+					verdict = i18n.T("bench.ok")
+				}
+			}
+			fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\t%d\t%.3f\t%s\n", name, i18n.Algorithm(algorithm), i18n.Status(result.Status.String()), cost, checked, result.ExpandenNodes, float64(result.TimeExe.Microseconds())/1000, verdict)
+		}
+	}
+	if err := table.Flush(); err != nil {
+		return false, err
+	}
+	fmt.Fprintln(w, i18n.T("bench.summary", runs, mismatches, invalid))
+	return mismatches == 0 && invalid == 0, nil
+}
+
+// validatePathFile checks a path saved with -save-path against a map and
+// prints the report. It returns false if the path isn't a valid solution
+func validatePathFile(w io.Writer, mapName, pathFile string) (bool, error) {
+	matrix, err := utils.GetMatrix(mapName)
+	if err != nil {
+		return false, err
+	}
+	file, err := os.Open(pathFile)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+	path, err := validator.ReadPath(file)
+	if err != nil {
+		return false, err
+	}
+
+	report := validator.Check(matrix, path)
+	printReport(w, report)
+	return report.Valid(), nil
+}
+
+func printReport(w io.Writer, report validator.Report) {
+	if report.Valid() {
+		fmt.Fprintln(w, i18n.T("validator.valid", report.Moves, report.Cost))
+		return
+	}
+	fmt.Fprintln(w, i18n.T("validator.invalid"))
+	for _, problem := range report.Problems {
+		fmt.Fprintln(w, "  -", problem)
+	}
+}
+
+// savePath writes the path of a solution in the format -validate reads
+func savePath(name string, path []datatypes.BoardCoordinate) error {
+	file, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := validator.WritePath(file, path); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -16,6 +16,8 @@ type AgentStep struct {
 	Cost             int
 	CurrentPosition  BoardCoordinate
 	PreviousPosition BoardCoordinate
+	// Identify the step and the one that generated it within a search, 0 is none
+	ID, ParentID int
 }
 
 type ByAction []AgentStep
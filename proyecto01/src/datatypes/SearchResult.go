@@ -2,10 +2,51 @@ package datatypes
 
 import "time"
 
+// SearchStatus tells how a search ended
+type SearchStatus int
+
+const (
+	// The search explored everything it could without reaching the goal
+	NoSolution SearchStatus = iota
+	Solved
+	// The context of the search was cancelled
+	Cancelled
+	// The search was stopped by one of its limits before finishing
+	TimeLimitReached
+	ExpansionLimitReached
+	MemoryLimitReached
+)
+
+// LimitReached tells if the search was stopped by a limit, so not finding a
+// solution doesn't mean there isn't one
+func (s SearchStatus) LimitReached() bool {
+	return s == TimeLimitReached || s == ExpansionLimitReached || s == MemoryLimitReached
+}
+
+func (s SearchStatus) String() string {
+	switch s {
+	case Solved:
+		return "solved"
+	case Cancelled:
+		return "cancelled"
+	case TimeLimitReached:
+		return "time limit reached"
+	case ExpansionLimitReached:
+		return "expansion limit reached"
+	case MemoryLimitReached:
+		return "memory limit reached"
+	default:
+		return "no solution"
+	}
+}
+
 type SearchResult struct {
 	PathFound                []BoardCoordinate
 	SolutionFound            bool
 	ExpandenNodes, TreeDepth int
 	Cost                     float32
 	TimeExe                  time.Duration
+	// Cells in the order the search expanded them
+	Explored []BoardCoordinate
+	Status   SearchStatus
 }
@@ -0,0 +1,206 @@
+// Package directions turns the path of a search into instructions for a
+// driver: consecutive moves the same way through the same traffic are
+// merged in one instruction with their cost, and picking up and leaving the
+// passenger get their own
+package directions
+
+import (
+	"fmt"
+
+	"github.com/Krud3/InteligenciaArtificial/src/datatypes"
+	"github.com/Krud3/InteligenciaArtificial/src/i18n"
+	"github.com/Krud3/InteligenciaArtificial/src/searchAlgorithms"
+)
+
+type Kind int
+
+const (
+	Drive Kind = iota
+	PickUp
+	Arrive
+)
+
+// Turn is the change of direction at the start of an instruction, relative
+// to where the car was going
+type Turn int
+
+const (
+	Start Turn = iota // The first move, the car has no direction yet
+	Straight
+	Right
+	Around
+	Left
+)
+
+// Traffic of the cells an instruction goes through
+type Traffic int
+
+const (
+	Free Traffic = iota
+	Medium
+	Heavy
+)
+
+// Instruction is a stretch of the route, or a stop at the passenger or the goal
+type Instruction struct {
+	Kind      Kind
+	Turn      Turn
+	Direction datatypes.AgentAction
+	Traffic   Traffic
+	Blocks    int
+	Cost      float32
+	From, To  datatypes.BoardCoordinate
+	// Moves of the route done when the instruction ends
+	End int
+}
+
+// TrafficOf is the traffic of a cell value, the special cells are free road
+func TrafficOf(cellValue int) Traffic {
+	switch cellValue {
+	case searchAlgorithms.MIDCOST:
+		return Medium
+	case searchAlgorithms.HEAVYCOST:
+		return Heavy
+	default:
+		return Free
+	}
+}
+
+// directionOf is the way a move between two neighbouring cells goes, UP towards the first row
+func directionOf(from, to datatypes.BoardCoordinate) datatypes.AgentAction {
+	switch {
+	case to.X < from.X:
+		return datatypes.UP
+	case to.X > from.X:
+		return datatypes.DOWN
+	case to.Y > from.Y:
+		return datatypes.RIGHT
+	default:
+		return datatypes.LEFT
+	}
+}
+
+// turnBetween relies on UP, RIGHT, DOWN and LEFT going clockwise
+func turnBetween(previous, next datatypes.AgentAction) Turn {
+	switch (next - previous + 4) % 4 {
+	case 0:
+		return Straight
+	case 1:
+		return Right
+	case 2:
+		return Around
+	default:
+		return Left
+	}
+}
+
+// Generate returns the instructions of a path over a map. The path can start
+// with the start cell or with the first move, like the algorithms return it
+func Generate(matrix datatypes.ScannedMatrix, path []datatypes.BoardCoordinate) []Instruction {
+	start := matrix.MainCoordinates["init"]
+	passenger, hasPassenger := matrix.MainCoordinates["passenger"]
+	goal := matrix.MainCoordinates["goal"]
+	if len(path) > 0 && path[0] == start {
+		path = path[1:]
+	}
+
+	var instructions []Instruction
+	var current *Instruction
+	position := start
+	pickedUp := false
+	for i, cell := range path {
+		direction := directionOf(position, cell)
+		value := matrix.Matrix[cell.X][cell.Y]
+		traffic := TrafficOf(value)
+		if current == nil || current.Direction != direction || current.Traffic != traffic {
+			turn := Start
+			if current != nil {
+				turn = turnBetween(current.Direction, direction)
+			} else if len(instructions) > 0 {
+				// After a stop the turn is relative to the last stretch
+				turn = turnBetween(lastDirection(instructions), direction)
+			}
+			instructions = append(instructions, Instruction{Kind: Drive, Turn: turn, Direction: direction, Traffic: traffic, From: position})
+			current = &instructions[len(instructions)-1]
+		}
+		current.Blocks++
+		current.Cost += searchAlgorithms.CellCost(value)
+		current.To = cell
+		current.End = i + 1
+		position = cell
+
+		switch {
+		case hasPassenger && !pickedUp && cell == passenger:
+			pickedUp = true
+			instructions = append(instructions, Instruction{Kind: PickUp, From: cell, To: cell, End: i + 1})
+			current = nil
+		case pickedUp && cell == goal:
+			instructions = append(instructions, Instruction{Kind: Arrive, From: cell, To: cell, End: i + 1, Cost: totalCost(instructions)})
+			current = nil
+		}
+	}
+	return instructions
+}
+
+// lastDirection is the direction of the last stretch driven
+func lastDirection(instructions []Instruction) datatypes.AgentAction {
+	for i := len(instructions) - 1; i >= 0; i-- {
+		if instructions[i].Kind == Drive {
+			return instructions[i].Direction
+		}
+	}
+	return datatypes.RIGHT
+}
+
+// totalCost adds the cost of every stretch, the Arrive instruction carries it
+func totalCost(instructions []Instruction) float32 {
+	var total float32
+	for _, instruction := range instructions {
+		if instruction.Kind == Drive {
+			total += instruction.Cost
+		}
+	}
+	return total
+}
+
+// Current returns the index of the instruction being followed after the
+// first moves of the route, the last one once the route is done
+func Current(instructions []Instruction, moves int) int {
+	for i, instruction := range instructions {
+		if instruction.End > moves {
+			return i
+		}
+	}
+	return len(instructions) - 1
+}
+
+var (
+	directionKeys = map[datatypes.AgentAction]string{datatypes.UP: "directions.up", datatypes.RIGHT: "directions.right", datatypes.DOWN: "directions.down", datatypes.LEFT: "directions.left"}
+	turnKeys      = map[Turn]string{Start: "directions.start", Straight: "directions.straight", Right: "directions.turnRight", Around: "directions.turnAround", Left: "directions.turnLeft"}
+	trafficKeys   = map[Traffic]string{Free: "directions.free", Medium: "directions.medium", Heavy: "directions.heavy"}
+)
+
+// String is the instruction in the current language of i18n
+func (in Instruction) String() string {
+	switch in.Kind {
+	case PickUp:
+		return i18n.T("directions.pickUp")
+	case Arrive:
+		return i18n.T("directions.arrive", in.Cost)
+	}
+	blocks := i18n.T("directions.blocks", in.Blocks)
+	if in.Blocks == 1 {
+		blocks = i18n.T("directions.block")
+	}
+	// The turns don't repeat the direction, their texts skip the first argument
+	return i18n.T(turnKeys[in.Turn], i18n.T(directionKeys[in.Direction]), blocks, i18n.T(trafficKeys[in.Traffic]), in.Cost)
+}
+
+// Text returns the instructions numbered, one per line
+func Text(instructions []Instruction) string {
+	text := ""
+	for i, instruction := range instructions {
+		text += fmt.Sprintf("%d. %s\n", i+1, instruction)
+	}
+	return text
+}
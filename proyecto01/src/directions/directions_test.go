@@ -0,0 +1,86 @@
+package directions
+
+import (
+	"testing"
+
+	"github.com/Krud3/InteligenciaArtificial/src/datatypes"
+)
+
+// 2 0 0 3 5
+// 1 1 0 1 1
+// 1 6 4 1 1
+var route = datatypes.ScannedMatrix{
+	Matrix: datatypes.Matrix{{2, 0, 0, 3, 5}, {1, 1, 0, 1, 1}, {1, 6, 4, 1, 1}},
+	MainCoordinates: map[string]datatypes.BoardCoordinate{
+		"init":      {X: 0, Y: 0},
+		"passenger": {X: 0, Y: 4},
+		"goal":      {X: 2, Y: 1},
+	},
+}
+
+// Right to the passenger through medium traffic, back the same way and down
+// to the goal through heavy traffic
+var routePath = []datatypes.BoardCoordinate{
+	{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 0, Y: 2}, {X: 0, Y: 3}, {X: 0, Y: 4},
+	{X: 0, Y: 3}, {X: 0, Y: 2}, {X: 1, Y: 2}, {X: 2, Y: 2}, {X: 2, Y: 1},
+}
+
+func TestGenerate(t *testing.T) {
+	instructions := Generate(route, routePath)
+	for i, expected := range []struct {
+		kind      Kind
+		turn      Turn
+		direction datatypes.AgentAction
+		traffic   Traffic
+		blocks    int
+		cost      float32
+		end       int
+	}{
+		{Drive, Start, datatypes.RIGHT, Free, 2, 2, 2},
+		{Drive, Straight, datatypes.RIGHT, Medium, 1, 4, 3},
+		{Drive, Straight, datatypes.RIGHT, Free, 1, 1, 4},
+		{PickUp, Start, 0, Free, 0, 0, 4},
+		{Drive, Around, datatypes.LEFT, Medium, 1, 4, 5},
+		{Drive, Straight, datatypes.LEFT, Free, 1, 1, 6},
+		{Drive, Left, datatypes.DOWN, Free, 1, 1, 7},
+		{Drive, Straight, datatypes.DOWN, Heavy, 1, 7, 8},
+		{Drive, Right, datatypes.LEFT, Free, 1, 1, 9},
+		{Arrive, Start, 0, Free, 0, 21, 9},
+	} {
+		if i >= len(instructions) {
+			t.Fatalf("%d instructions, expected more: %v", len(instructions), instructions)
+		}
+		in := instructions[i]
+		if in.Kind != expected.kind || in.Turn != expected.turn || in.Blocks != expected.blocks || in.Cost != expected.cost || in.End != expected.end {
+			t.Errorf("instruction %d: kind %d, turn %d, %d blocks, cost %v, ends at %d; expected kind %d, turn %d, %d blocks, cost %v, ends at %d",
+				i, in.Kind, in.Turn, in.Blocks, in.Cost, in.End, expected.kind, expected.turn, expected.blocks, expected.cost, expected.end)
+		}
+		if in.Direction != expected.direction || in.Traffic != expected.traffic {
+			t.Errorf("instruction %d: direction %d in traffic %d, expected %d in %d", i, in.Direction, in.Traffic, expected.direction, expected.traffic)
+		}
+	}
+	if len(instructions) != 10 {
+		t.Fatalf("%d instructions, expected 10", len(instructions))
+	}
+}
+
+func TestGenerateWithoutStartCell(t *testing.T) {
+	withStart, withoutStart := Generate(route, routePath), Generate(route, routePath[1:])
+	if len(withStart) != len(withoutStart) {
+		t.Fatalf("%d instructions from the start cell, %d without it", len(withStart), len(withoutStart))
+	}
+	for i := range withStart {
+		if withStart[i] != withoutStart[i] {
+			t.Fatalf("instruction %d: %+v from the start cell, %+v without it", i, withStart[i], withoutStart[i])
+		}
+	}
+}
+
+func TestCurrent(t *testing.T) {
+	instructions := Generate(route, routePath)
+	for _, test := range []struct{ moves, index int }{{0, 0}, {1, 0}, {2, 1}, {4, 4}, {8, 8}, {9, 9}, {20, 9}} {
+		if index := Current(instructions, test.moves); index != test.index {
+			t.Errorf("after %d moves instruction %d, expected %d", test.moves, index, test.index)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+// Package assets embeds the images of the game, so the binary doesn't depend
+// on the directory it's started from
+package assets
+
+import (
+	"embed"
+	"image"
+	"image/png"
+
+	"github.com/Krud3/InteligenciaArtificial/src/i18n"
+)
+
+//go:embed images/*.png
+var images embed.FS
+
+// Decode returns one of the embedded images by its file name, like "muro-1.png"
+func Decode(name string) (image.Image, error) {
+	file, err := images.Open("images/" + name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	img, err := png.Decode(file)
+	if err != nil {
+		return nil, i18n.Errorf("error.decode", name, err)
+	}
+	return img, nil
+}
@@ -0,0 +1,237 @@
+package game
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/Krud3/InteligenciaArtificial/src/input"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	minZoom = 0.1
+	maxZoom = 4
+	// Zoom change per notch of the wheel
+	zoomStep = 1.15
+	// Pixels of the screen the arrows pan per frame
+	panSpeed = 8
+	// Part of the distance to the car covered each frame while following it
+	followSmoothing = 0.15
+	// Longest side of the minimap
+	minimapSize = 160
+)
+
+var (
+	minimapBorderColor   = color.RGBA{255, 255, 255, 255}
+	minimapViewportColor = color.RGBA{255, 220, 0, 255}
+)
+
+// Camera shows part of the board, the world, in a viewport of the screen.
+// The wheel zooms, dragging or Shift+arrows pan, F follows the car again and
+// V fits the whole board. The minimap in the corner can be clicked to jump
+type Camera struct {
+	Viewport   image.Rectangle
+	X, Y       float64 // Point of the world at the center of the viewport
+	Zoom       float64
+	Follow     bool
+	world      image.Point // Size of the world in pixels
+	dragging   bool
+	dragFrom   image.Point
+	onMinimap  bool // The drag started on the minimap
+	fitPending bool // Fit once the first viewport is known
+}
+
+// NewCamera returns a camera that fits the whole world and follows the car
+func NewCamera(worldWidth, worldHeight int) *Camera {
+	return &Camera{world: image.Pt(worldWidth, worldHeight), Zoom: 1, Follow: true, fitPending: true}
+}
+
+// SetViewport changes the part of the screen the camera draws on, it's
+// called every frame since the window can be resized
+func (c *Camera) SetViewport(viewport image.Rectangle) {
+	c.Viewport = viewport
+	if c.fitPending && !viewport.Empty() {
+		c.fitPending = false
+		c.Fit()
+	}
+	c.clamp()
+}
+
+// Fit zooms so the whole world is visible, without making it bigger than its size
+func (c *Camera) Fit() {
+	if c.world.X == 0 || c.world.Y == 0 {
+		return
+	}
+	c.Zoom = math.Min(1, math.Min(float64(c.Viewport.Dx())/float64(c.world.X), float64(c.Viewport.Dy())/float64(c.world.Y)))
+	c.Zoom = math.Max(c.Zoom, minZoom)
+	c.X, c.Y = float64(c.world.X)/2, float64(c.world.Y)/2
+}
+
+// Update handles the input of the camera. target is the point of the world
+// followed, nil if there's nothing to follow
+func (c *Camera) Update(target *image.Point) {
+	cursor := input.Cursor()
+
+	if input.Pressed(ebiten.KeyF) {
+		c.Follow = !c.Follow
+	}
+	if input.Pressed(ebiten.KeyV) {
+		c.Follow = false
+		c.Fit()
+	}
+
+	if cursor.In(c.Viewport) {
+		if _, dy := ebiten.Wheel(); dy != 0 {
+			c.zoomAt(cursor, math.Pow(zoomStep, dy))
+		}
+	}
+
+	// Dragging with any button pans, on the minimap it moves the view there
+	pressed := inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) || inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight)
+	if pressed && cursor.In(c.Viewport) {
+		c.dragging = true
+		c.dragFrom = cursor
+		c.onMinimap = c.showMinimap() && cursor.In(c.minimapRect())
+	}
+	if !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) && !ebiten.IsMouseButtonPressed(ebiten.MouseButtonRight) {
+		c.dragging = false
+	}
+	if c.dragging {
+		if c.onMinimap {
+			rect := c.minimapRect()
+			scale := float64(rect.Dx()) / float64(c.world.X)
+			c.X = float64(cursor.X-rect.Min.X) / scale
+			c.Y = float64(cursor.Y-rect.Min.Y) / scale
+			c.Follow = false
+		} else if cursor != c.dragFrom {
+			c.X -= float64(cursor.X-c.dragFrom.X) / c.Zoom
+			c.Y -= float64(cursor.Y-c.dragFrom.Y) / c.Zoom
+			c.dragFrom = cursor
+			c.Follow = false
+		}
+	}
+
+	// Shift+arrows, the arrows alone drive or step the car
+	if input.Shift() {
+		for key, direction := range map[ebiten.Key]image.Point{
+			ebiten.KeyArrowUp:    {0, -1},
+			ebiten.KeyArrowRight: {1, 0},
+			ebiten.KeyArrowDown:  {0, 1},
+			ebiten.KeyArrowLeft:  {-1, 0},
+		} {
+			if ebiten.IsKeyPressed(key) {
+				c.X += float64(direction.X) * panSpeed / c.Zoom
+				c.Y += float64(direction.Y) * panSpeed / c.Zoom
+				c.Follow = false
+			}
+		}
+	}
+
+	if c.Follow && target != nil {
+		c.X += (float64(target.X) - c.X) * followSmoothing
+		c.Y += (float64(target.Y) - c.Y) * followSmoothing
+	}
+	c.clamp()
+}
+
+// zoomAt changes the zoom keeping the point of the world under the cursor still
+func (c *Camera) zoomAt(cursor image.Point, factor float64) {
+	worldX, worldY := c.ScreenToWorld(cursor)
+	c.Zoom = math.Max(minZoom, math.Min(c.Zoom*factor, maxZoom))
+	centerX, centerY := c.center()
+	c.X = worldX - (float64(cursor.X)-centerX)/c.Zoom
+	c.Y = worldY - (float64(cursor.Y)-centerY)/c.Zoom
+}
+
+// clamp keeps the world inside the view, a world smaller than the view is centered
+func (c *Camera) clamp() {
+	clampAxis := func(position float64, world int, view int) float64 {
+		half := float64(view) / 2 / c.Zoom
+		if 2*half >= float64(world) {
+			return float64(world) / 2
+		}
+		return math.Max(half, math.Min(position, float64(world)-half))
+	}
+	c.X = clampAxis(c.X, c.world.X, c.Viewport.Dx())
+	c.Y = clampAxis(c.Y, c.world.Y, c.Viewport.Dy())
+}
+
+// center is the point of the screen in the middle of the viewport
+func (c *Camera) center() (float64, float64) {
+	return float64(c.Viewport.Min.X) + float64(c.Viewport.Dx())/2, float64(c.Viewport.Min.Y) + float64(c.Viewport.Dy())/2
+}
+
+// GeoM moves the world to the screen
+func (c *Camera) GeoM() ebiten.GeoM {
+	var geoM ebiten.GeoM
+	geoM.Translate(-c.X, -c.Y)
+	geoM.Scale(c.Zoom, c.Zoom)
+	geoM.Translate(c.center())
+	return geoM
+}
+
+// ScreenToWorld returns the point of the world under a point of the screen
+func (c *Camera) ScreenToWorld(p image.Point) (float64, float64) {
+	centerX, centerY := c.center()
+	return c.X + (float64(p.X)-centerX)/c.Zoom, c.Y + (float64(p.Y)-centerY)/c.Zoom
+}
+
+// CellAt returns the row and column under a point of the screen, false if
+// it's outside of the board
+func (c *Camera) CellAt(p image.Point) (int, int, bool) {
+	if !p.In(c.Viewport) {
+		return 0, 0, false
+	}
+	x, y := c.ScreenToWorld(p)
+	if x < 0 || y < 0 || x >= float64(c.world.X) || y >= float64(c.world.Y) {
+		return 0, 0, false
+	}
+	return int(y) / TileSize, int(x) / TileSize, true
+}
+
+// Draw draws the world in the viewport, and the minimap if part of it is hidden
+func (c *Camera) Draw(screen *ebiten.Image, world *ebiten.Image) {
+	view := screen.SubImage(c.Viewport).(*ebiten.Image)
+	op := &ebiten.DrawImageOptions{GeoM: c.GeoM()}
+	if c.Zoom < 1 {
+		op.Filter = ebiten.FilterLinear
+	}
+	view.DrawImage(world, op)
+
+	if c.showMinimap() {
+		c.drawMinimap(screen, world)
+	}
+}
+
+// showMinimap is true when the world doesn't fit in the viewport
+func (c *Camera) showMinimap() bool {
+	return float64(c.world.X)*c.Zoom > float64(c.Viewport.Dx())+1 || float64(c.world.Y)*c.Zoom > float64(c.Viewport.Dy())+1
+}
+
+// minimapRect is in the top right corner of the viewport, with the shape of the world
+func (c *Camera) minimapRect() image.Rectangle {
+	scale := minimapSize / float64(max(c.world.X, c.world.Y))
+	width, height := int(float64(c.world.X)*scale), int(float64(c.world.Y)*scale)
+	return image.Rect(c.Viewport.Max.X-width-10, c.Viewport.Min.Y+10, c.Viewport.Max.X-10, c.Viewport.Min.Y+10+height)
+}
+
+func (c *Camera) drawMinimap(screen *ebiten.Image, world *ebiten.Image) {
+	rect := c.minimapRect()
+	scale := float64(rect.Dx()) / float64(c.world.X)
+	ebitenutil.DrawRect(screen, float64(rect.Min.X-2), float64(rect.Min.Y-2), float64(rect.Dx()+4), float64(rect.Dy()+4), minimapBorderColor)
+	op := &ebiten.DrawImageOptions{Filter: ebiten.FilterLinear}
+	op.GeoM.Scale(scale, scale)
+	op.GeoM.Translate(float64(rect.Min.X), float64(rect.Min.Y))
+	screen.DrawImage(world, op)
+
+	// The part of the world in the viewport
+	left, top := c.ScreenToWorld(c.Viewport.Min)
+	right, bottom := c.ScreenToWorld(c.Viewport.Max)
+	left, top = math.Max(left, 0), math.Max(top, 0)
+	right, bottom = math.Min(right, float64(c.world.X)), math.Min(bottom, float64(c.world.Y))
+	vector.StrokeRect(screen, float32(float64(rect.Min.X)+left*scale), float32(float64(rect.Min.Y)+top*scale), float32((right-left)*scale), float32((bottom-top)*scale), 2, minimapViewportColor, false)
+}
@@ -0,0 +1,291 @@
+package game
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"log"
+	"math"
+
+	"github.com/Krud3/InteligenciaArtificial/src/datatypes"
+	"github.com/Krud3/InteligenciaArtificial/src/game/entities"
+	"github.com/Krud3/InteligenciaArtificial/src/i18n"
+	"github.com/Krud3/InteligenciaArtificial/src/visuals/components"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// Height of the label over each pane
+const paneLabelHeight = 16
+
+// Buttons of the comparison, in the bar under the panes or the table
+var (
+	tableButtonRect       = image.Rect(10, MaxSize*TileSize+10, 130, MaxSize*TileSize+50)
+	replayButtonRect      = image.Rect(140, MaxSize*TileSize+10, 260, MaxSize*TileSize+50)
+	compareBackButtonRect = image.Rect(MaxSize*TileSize-130, MaxSize*TileSize+10, MaxSize*TileSize-10, MaxSize*TileSize+50)
+)
+
+// comparisonPane is one algorithm driving its own copy of the map
+type comparisonPane struct {
+	name      string
+	result    datatypes.SearchResult
+	err       error
+	car       *entities.Car
+	pickedUp  bool
+	boardView *ebiten.Image
+}
+
+// Comparison runs several algorithms over the same map, animates their cars
+// at the same time and then shows their stats side by side
+type Comparison struct {
+	scene     *Scene
+	matrix    datatypes.ScannedMatrix
+	panes     []*comparisonPane
+	table     *components.Table
+	showTable bool
+	// The searches run in the background one after the other, so their
+	// times can be compared. next is the pane of the running one
+	search *BackgroundSearch
+	next   int
+	// Optional, receives the result of every search that ends without error
+	OnResult func(name string, result datatypes.SearchResult)
+	Done     bool
+}
+
+func NewComparison(scene *Scene, matrix datatypes.ScannedMatrix, algorithms []string) (*Comparison, error) {
+	comparison := &Comparison{scene: scene, matrix: matrix}
+	boardWidth, boardHeight := scene.Size()
+	comparison.table = components.NewTable(image.Pt(40, 90),
+		components.Column{Title: i18n.T("common.algorithm"), Width: 180},
+		components.Column{Title: i18n.T("common.cost"), Width: 60},
+		components.Column{Title: i18n.T("compare.expanded"), Width: 80},
+		components.Column{Title: i18n.T("compare.depth"), Width: 60},
+		components.Column{Title: i18n.T("common.timeMs"), Width: 80},
+		components.Column{Title: i18n.T("common.status"), Width: 100},
+	)
+	comparison.table.Sortable = true
+	for _, name := range algorithms {
+		car, err := entities.NewCar(scene.CarPosX, scene.CarPosY)
+		if err != nil {
+			return nil, err
+		}
+		pane := &comparisonPane{
+			name:      name,
+			car:       car,
+			boardView: ebiten.NewImage(max(boardWidth, 1), max(boardHeight, 1)),
+		}
+		comparison.panes = append(comparison.panes, pane)
+	}
+	comparison.startNext()
+	return comparison, nil
+}
+
+// startNext starts the search of the next pane, once every search ended the
+// cars start driving
+func (c *Comparison) startNext() {
+	if c.next < len(c.panes) {
+		c.search = StartBackgroundSearch(c.panes[c.next].name, c.matrix)
+		return
+	}
+	c.search = nil
+	c.table.SetRows(c.tableRows())
+	c.Replay()
+}
+
+// pollSearch gives its pane the result of the running search once it ends
+func (c *Comparison) pollSearch() {
+	outcome, done := c.search.Poll()
+	if !done {
+		return
+	}
+	pane := c.panes[c.next]
+	pane.result, pane.err = outcome.result, outcome.err
+	if pane.err != nil {
+		log.Print(i18n.T("log.runAlgorithm", pane.name, pane.err))
+	} else if c.OnResult != nil {
+		c.OnResult(pane.name, pane.result)
+	}
+	var path [][]int
+	for _, coord := range pane.result.PathFound {
+		path = append(path, []int{coord.X, coord.Y})
+	}
+	pane.car.SetPath(path)
+	c.next++
+	c.startNext()
+}
+
+// Replay puts every car back at the start
+func (c *Comparison) Replay() {
+	for _, pane := range c.panes {
+		pane.car.Seek(0)
+		pane.car.SetImageWithoutPassenger()
+		pane.pickedUp = false
+	}
+	c.showTable = false
+}
+
+// Finished tells if every car reached the end of its path
+func (c *Comparison) Finished() bool {
+	for _, pane := range c.panes {
+		if pane.car.Index < len(pane.car.Path) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Comparison) Update() {
+	if c.search != nil {
+		if c.search.CancelRequested() {
+			c.search.Cancel()
+			c.search = nil
+			c.Done = true
+			return
+		}
+		c.pollSearch()
+		return
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyT) {
+		c.showTable = !c.showTable
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyR) {
+		c.Replay()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		c.Done = true
+	}
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		cursor := image.Pt(ebiten.CursorPosition())
+		switch {
+		case cursor.In(tableButtonRect):
+			c.showTable = !c.showTable
+		case cursor.In(replayButtonRect):
+			c.Replay()
+		case cursor.In(compareBackButtonRect):
+			c.Done = true
+		}
+	}
+
+	if c.showTable {
+		c.table.Update()
+		return
+	}
+	for _, pane := range c.panes {
+		pane.car.Update()
+		if pane.car.PosX == c.scene.PassengerPosX && pane.car.PosY == c.scene.PassengerPosY && !pane.pickedUp {
+			pane.pickedUp = true
+			pane.car.SetImageWithPassenger()
+		}
+	}
+	// The table comes up on its own once the last car arrives
+	if c.Finished() {
+		c.showTable = true
+	}
+}
+
+func (c *Comparison) Draw(screen *ebiten.Image) {
+	if c.search != nil {
+		// The cars wait at the start until every search ended
+		c.DrawPanes(screen)
+		c.search.Draw(screen)
+		return
+	}
+	if c.showTable {
+		c.DrawTable(screen)
+	} else {
+		c.DrawPanes(screen)
+	}
+
+	tableLabel := i18n.T("compare.table")
+	if c.showTable {
+		tableLabel = i18n.T("compare.cars")
+	}
+	for _, button := range []struct {
+		rect  image.Rectangle
+		label string
+		color color.RGBA
+	}{
+		{tableButtonRect, tableLabel, color.RGBA{60, 60, 160, 255}},
+		{replayButtonRect, i18n.T("common.replay"), color.RGBA{60, 60, 160, 255}},
+		{compareBackButtonRect, i18n.T("common.backToMenu"), color.RGBA{255, 0, 0, 255}},
+	} {
+		ebitenutil.DrawRect(screen, float64(button.rect.Min.X), float64(button.rect.Min.Y), float64(button.rect.Dx()), float64(button.rect.Dy()), button.color)
+		ebitenutil.DebugPrintAt(screen, button.label, button.rect.Min.X+20, button.rect.Min.Y+12)
+	}
+}
+
+// DrawPanes draws a scaled down board per algorithm, in a grid that fills the screen
+func (c *Comparison) DrawPanes(screen *ebiten.Image) {
+	columns := 2
+	if len(c.panes) > 4 {
+		columns = 3
+	}
+	rows := (len(c.panes) + columns - 1) / columns
+	screenSize := float64(MaxSize * TileSize)
+	boardWidth, boardHeight := c.scene.Size()
+	paneWidth := screenSize / float64(columns)
+	// Boards of any shape fit in their pane
+	scale := math.Min(paneWidth/float64(max(boardWidth, 1)), (screenSize/float64(rows)-paneLabelHeight)/float64(max(boardHeight, 1)))
+
+	for i, pane := range c.panes {
+		pane.boardView.Clear()
+		c.scene.Draw(pane.boardView)
+		if !pane.pickedUp {
+			op := &ebiten.DrawImageOptions{}
+			op.GeoM.Translate(float64(c.scene.PassengerPosX*TileSize), float64(c.scene.PassengerPosY*TileSize))
+			pane.boardView.DrawImage(c.scene.Images[Passenger], op)
+		}
+		pane.boardView.DrawImage(pane.car.Image, pane.car.DrawOptions(TileSize))
+
+		x := float64(i%columns) * paneWidth
+		y := float64(i/columns) * (float64(boardHeight)*scale + paneLabelHeight)
+		label := i18n.T("compare.step", i18n.Algorithm(pane.name), pane.car.Index, len(pane.car.Path))
+		if pane.err != nil {
+			label = i18n.Algorithm(pane.name) + ": " + pane.err.Error()
+		}
+		ebitenutil.DebugPrintAt(screen, label, int(x)+4, int(y))
+
+		boardOp := &ebiten.DrawImageOptions{}
+		boardOp.GeoM.Scale(scale, scale)
+		boardOp.GeoM.Translate(x, y+paneLabelHeight)
+		boardOp.Filter = ebiten.FilterLinear
+		screen.DrawImage(pane.boardView, boardOp)
+	}
+}
+
+// tableRows are the stats of every algorithm, the cheapest solutions are highlighted
+func (c *Comparison) tableRows() []components.TableRow {
+	bestCost := float32(math.MaxFloat32)
+	for _, pane := range c.panes {
+		if pane.err == nil && pane.result.SolutionFound {
+			bestCost = min(bestCost, pane.result.Cost)
+		}
+	}
+	var rows []components.TableRow
+	for _, pane := range c.panes {
+		if pane.err != nil {
+			rows = append(rows, components.TableRow{Cells: []string{i18n.Algorithm(pane.name), pane.err.Error()}})
+			continue
+		}
+		result := pane.result
+		rows = append(rows, components.TableRow{
+			Cells: []string{
+				i18n.Algorithm(pane.name),
+				fmt.Sprintf("%.0f", result.Cost),
+				fmt.Sprint(result.ExpandenNodes),
+				fmt.Sprint(result.TreeDepth),
+				fmt.Sprintf("%.3f", float64(result.TimeExe.Microseconds())/1000),
+				i18n.Status(result.Status.String()),
+			},
+			Highlight: result.SolutionFound && result.Cost == bestCost,
+		})
+	}
+	return rows
+}
+
+// DrawTable compares the stats of every algorithm, a click on a header sorts by it
+func (c *Comparison) DrawTable(screen *ebiten.Image) {
+	ebitenutil.DebugPrintAt(screen, i18n.T("compare.title"), 50, 50)
+	c.table.Draw(screen)
+}
@@ -0,0 +1,87 @@
+package game
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+
+	"github.com/Krud3/InteligenciaArtificial/src/datatypes"
+	"github.com/Krud3/InteligenciaArtificial/src/directions"
+	"github.com/Krud3/InteligenciaArtificial/src/i18n"
+	"github.com/Krud3/InteligenciaArtificial/src/input"
+	"github.com/Krud3/InteligenciaArtificial/src/visuals"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+const (
+	directionsPanelWidth = 420
+	// Instructions shown at once, the list follows the car
+	directionsVisibleLines = 12
+	directionsLineHeight   = 18
+)
+
+var (
+	// Tab in the corner of the board that opens the panel, D does the same
+	directionsTabRect     = image.Rect(8, 8, 160, 30)
+	directionsPanelColor  = color.RGBA{0, 0, 0, 190}
+	directionsTabColor    = color.RGBA{60, 60, 160, 255}
+	directionsCharsPerRow = (directionsPanelWidth - 16) / 6
+)
+
+// DirectionsPanel shows the turn by turn directions of the path of the car,
+// with the one being followed highlighted
+type DirectionsPanel struct {
+	instructions []directions.Instruction
+	// The path of the car starts with the start cell, it isn't a move
+	skipFirst bool
+	Open      bool
+}
+
+func NewDirectionsPanel(matrix datatypes.ScannedMatrix, path []datatypes.BoardCoordinate) *DirectionsPanel {
+	return &DirectionsPanel{
+		instructions: directions.Generate(matrix, path),
+		skipFirst:    len(path) > 0 && path[0] == matrix.MainCoordinates["init"],
+	}
+}
+
+func (p *DirectionsPanel) Update() {
+	if input.Pressed(ebiten.KeyD) || input.Clicked(directionsTabRect) {
+		p.Open = !p.Open
+	}
+}
+
+// Draw draws the tab and, if it's open, the instructions around the one
+// the car is following after pathIndex steps of its path
+func (p *DirectionsPanel) Draw(screen *ebiten.Image, pathIndex int) {
+	if len(p.instructions) == 0 {
+		return
+	}
+	ebitenutil.DrawRect(screen, float64(directionsTabRect.Min.X), float64(directionsTabRect.Min.Y), float64(directionsTabRect.Dx()), float64(directionsTabRect.Dy()), directionsTabColor)
+	ebitenutil.DebugPrintAt(screen, i18n.T("directions.toggle"), directionsTabRect.Min.X+8, directionsTabRect.Min.Y+3)
+	if !p.Open {
+		return
+	}
+
+	moves := pathIndex
+	if p.skipFirst {
+		moves = max(0, moves-1)
+	}
+	current := directions.Current(p.instructions, moves)
+	first := max(0, min(current-directionsVisibleLines/3, len(p.instructions)-directionsVisibleLines))
+	last := min(first+directionsVisibleLines, len(p.instructions))
+
+	top := directionsTabRect.Max.Y + 4
+	ebitenutil.DrawRect(screen, float64(directionsTabRect.Min.X), float64(top), directionsPanelWidth, float64((last-first)*directionsLineHeight+8), directionsPanelColor)
+	for i := first; i < last; i++ {
+		y := top + 4 + (i-first)*directionsLineHeight
+		if i == current {
+			ebitenutil.DrawRect(screen, float64(directionsTabRect.Min.X), float64(y-1), directionsPanelWidth, directionsLineHeight, visuals.HighlightColor)
+		}
+		line := []rune(fmt.Sprintf("%d. %s", i+1, p.instructions[i]))
+		if len(line) > directionsCharsPerRow {
+			line = line[:directionsCharsPerRow]
+		}
+		ebitenutil.DebugPrintAt(screen, string(line), directionsTabRect.Min.X+8, y)
+	}
+}
@@ -0,0 +1,266 @@
+package game
+
+import (
+	"image"
+	"image/color"
+	"log"
+
+	"github.com/Krud3/InteligenciaArtificial/src/datatypes"
+	"github.com/Krud3/InteligenciaArtificial/src/game/entities"
+	"github.com/Krud3/InteligenciaArtificial/src/i18n"
+	"github.com/Krud3/InteligenciaArtificial/src/input"
+	"github.com/Krud3/InteligenciaArtificial/src/searchAlgorithms"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+var (
+	humanRouteColor   = color.NRGBA{R: 255, G: 140, B: 0, A: 230}
+	optimalRouteColor = color.NRGBA{R: 0, G: 200, B: 255, A: 230}
+)
+
+// Buttons of the driving mode, in the bar under the board
+var (
+	retryButtonRect     = image.Rect(MaxSize*TileSize-260, MaxSize*TileSize+10, MaxSize*TileSize-140, MaxSize*TileSize+50)
+	driveBackButtonRect = image.Rect(MaxSize*TileSize-130, MaxSize*TileSize+10, MaxSize*TileSize-10, MaxSize*TileSize+50)
+)
+
+// Algorithms that give the optimal route the driver is scored against
+var optimalAlgorithms = []string{searchAlgorithms.UniformCostName, searchAlgorithms.AStarName}
+
+// Driving lets the user drive the taxi with the arrow keys, paying the same
+// costs as the algorithms, and compares the trip with the optimal one
+type Driving struct {
+	scene     *Scene
+	world     *ebiten.Image
+	camera    *Camera
+	matrix    datatypes.ScannedMatrix
+	car       *entities.Car
+	position  datatypes.BoardCoordinate // X is the row, Y the column
+	route     []datatypes.BoardCoordinate
+	cost      float32
+	pickedUp  bool
+	Arrived   bool
+	optimal   datatypes.SearchResult
+	optimalBy string
+	// The optimal route is searched in the background while the user drives,
+	// one algorithm after the other
+	search  *BackgroundSearch
+	pending []string
+	Done    bool
+}
+
+func NewDriving(scene *Scene, matrix datatypes.ScannedMatrix) (*Driving, error) {
+	start := matrix.MainCoordinates["init"]
+	car, err := entities.NewCar(start.Y, start.X)
+	if err != nil {
+		return nil, err
+	}
+	driving := &Driving{scene: scene, matrix: matrix, car: car}
+	driving.world, driving.camera = newBoardView(scene)
+	driving.pending = optimalAlgorithms
+	driving.nextSearch()
+	driving.Retry()
+	return driving, nil
+}
+
+// nextSearch starts the next algorithm of the optimal route
+func (d *Driving) nextSearch() {
+	d.search = nil
+	if len(d.pending) > 0 {
+		d.search = StartBackgroundSearch(d.pending[0], d.matrix)
+		d.pending = d.pending[1:]
+	}
+}
+
+// pollSearch keeps the cheapest route of the searches that ended
+func (d *Driving) pollSearch() {
+	if d.search == nil {
+		return
+	}
+	outcome, done := d.search.Poll()
+	if !done {
+		return
+	}
+	name, result := d.search.algorithm, outcome.result
+	if outcome.err != nil {
+		log.Print(i18n.T("log.runAlgorithm", name, outcome.err))
+	} else if result.SolutionFound && (d.optimalBy == "" || result.Cost < d.optimal.Cost) {
+		d.optimal, d.optimalBy = result, name
+	}
+	d.nextSearch()
+}
+
+// stopSearch cancels the searches of the optimal route, the trip is scored
+// against what they found so far
+func (d *Driving) stopSearch() {
+	if d.search != nil {
+		d.search.Cancel()
+	}
+	d.search, d.pending = nil, nil
+}
+
+// Retry puts the taxi back at the start
+func (d *Driving) Retry() {
+	d.position = d.matrix.MainCoordinates["init"]
+	d.route = []datatypes.BoardCoordinate{d.position}
+	d.cost = 0
+	d.pickedUp = false
+	d.Arrived = false
+	d.car.Seek(0)
+	d.car.SetImageWithoutPassenger()
+}
+
+func (d *Driving) Update() {
+	d.pollSearch()
+	// Once the taxi arrives the score waits for the searches, they can be cancelled
+	if d.Arrived && d.search != nil {
+		if d.search.CancelRequested() {
+			d.stopSearch()
+		}
+		return
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		d.Done = true
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyR) {
+		d.Retry()
+	}
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		cursor := image.Pt(ebiten.CursorPosition())
+		switch {
+		case cursor.In(retryButtonRect):
+			d.Retry()
+		case cursor.In(driveBackButtonRect):
+			d.Done = true
+		}
+	}
+	if d.Done {
+		d.stopSearch()
+		return
+	}
+	carCenter := image.Pt(d.car.PosX*TileSize+TileSize/2, d.car.PosY*TileSize+TileSize/2)
+	d.camera.Update(&carCenter)
+	// Shift+arrows move the camera
+	if d.Arrived || input.Shift() {
+		return
+	}
+
+	for key, offset := range map[ebiten.Key]datatypes.BoardCoordinate{
+		ebiten.KeyArrowUp:    {X: -1, Y: 0},
+		ebiten.KeyArrowRight: {X: 0, Y: 1},
+		ebiten.KeyArrowDown:  {X: 1, Y: 0},
+		ebiten.KeyArrowLeft:  {X: 0, Y: -1},
+	} {
+		if inpututil.IsKeyJustPressed(key) {
+			d.Move(datatypes.BoardCoordinate{X: d.position.X + offset.X, Y: d.position.Y + offset.Y})
+		}
+	}
+}
+
+// Move drives into a neighbour cell if the algorithms could move there too
+func (d *Driving) Move(next datatypes.BoardCoordinate) {
+	for _, move := range searchAlgorithms.Moves(d.matrix.Matrix, d.position) {
+		if move.Coordinate != next {
+			continue
+		}
+		d.position = next
+		d.route = append(d.route, next)
+		d.cost += searchAlgorithms.CellCost(d.matrix.Matrix[next.X][next.Y])
+		d.car.MoveTo(next.Y, next.X)
+		if next == d.matrix.MainCoordinates["passenger"] && !d.pickedUp {
+			d.pickedUp = true
+			d.car.SetImageWithPassenger()
+		}
+		// The trip only ends when the passenger is dropped at the goal
+		if next == d.matrix.MainCoordinates["goal"] && d.pickedUp {
+			d.Arrived = true
+		}
+		return
+	}
+}
+
+func (d *Driving) Draw(screen *ebiten.Image) {
+	d.world.Clear()
+	d.scene.Draw(d.world)
+	if !d.pickedUp {
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(float64(d.scene.PassengerPosX*TileSize), float64(d.scene.PassengerPosY*TileSize))
+		d.world.DrawImage(d.scene.Images[Passenger], op)
+	}
+
+	if d.Arrived {
+		// Both routes are shown side by side so the detours stand out
+		drawRouteLine(d.world, d.optimal.PathFound, d.matrix.MainCoordinates["init"], optimalRouteColor, 6)
+	}
+	drawRouteLine(d.world, d.route, d.matrix.MainCoordinates["init"], humanRouteColor, -6)
+
+	d.world.DrawImage(d.car.Image, d.car.DrawOptions(TileSize))
+	d.camera.Draw(screen, d.world)
+
+	status := i18n.T("drive.status", d.cost, len(d.route)-1)
+	if !d.pickedUp {
+		status += i18n.T("drive.pickUp")
+	} else if !d.Arrived {
+		status += i18n.T("drive.toGoal")
+	}
+	ebitenutil.DebugPrintAt(screen, status, 10, MaxSize*TileSize+10)
+	if d.Arrived {
+		d.drawScore(screen)
+	} else {
+		ebitenutil.DebugPrintAt(screen, i18n.T("drive.help"), 10, MaxSize*TileSize+30)
+	}
+
+	for _, button := range []struct {
+		rect  image.Rectangle
+		label string
+		color color.RGBA
+	}{
+		{retryButtonRect, i18n.T("common.retry"), color.RGBA{60, 60, 160, 255}},
+		{driveBackButtonRect, i18n.T("common.backToMenu"), color.RGBA{255, 0, 0, 255}},
+	} {
+		ebitenutil.DrawRect(screen, float64(button.rect.Min.X), float64(button.rect.Min.Y), float64(button.rect.Dx()), float64(button.rect.Dy()), button.color)
+		ebitenutil.DebugPrintAt(screen, button.label, button.rect.Min.X+20, button.rect.Min.Y+12)
+	}
+	if d.Arrived && d.search != nil {
+		d.search.Draw(screen)
+	}
+}
+
+// drawScore compares the trip of the driver with the optimal one
+func (d *Driving) drawScore(screen *ebiten.Image) {
+	if d.search != nil {
+		return
+	}
+	if d.optimalBy == "" {
+		ebitenutil.DebugPrintAt(screen, i18n.T("drive.noOptimal"), 10, MaxSize*TileSize+30)
+		return
+	}
+	optimalSteps := len(d.optimal.PathFound) - 1
+	if len(d.optimal.PathFound) > 0 && d.optimal.PathFound[0] != d.matrix.MainCoordinates["init"] {
+		optimalSteps++
+	}
+	score := 100.0
+	if d.cost > 0 {
+		score = float64(d.optimal.Cost) / float64(d.cost) * 100
+	}
+	ebitenutil.DebugPrintAt(screen, i18n.T("drive.score", i18n.Algorithm(d.optimalBy), d.optimal.Cost, optimalSteps, score), 10, MaxSize*TileSize+30)
+}
+
+// drawRouteLine joins the centers of the cells of a route, offset so two
+// routes over the same cells are both visible
+func drawRouteLine(screen *ebiten.Image, route []datatypes.BoardCoordinate, start datatypes.BoardCoordinate, clr color.Color, offset float32) {
+	if len(route) > 0 && route[0] != start {
+		route = append([]datatypes.BoardCoordinate{start}, route...)
+	}
+	center := func(cell datatypes.BoardCoordinate) (float32, float32) {
+		return float32(cell.Y*TileSize+TileSize/2) + offset, float32(cell.X*TileSize+TileSize/2) + offset
+	}
+	for i := 1; i < len(route); i++ {
+		x0, y0 := center(route[i-1])
+		x1, y1 := center(route[i])
+		vector.StrokeLine(screen, x0, y0, x1, y1, 4, clr, true)
+	}
+}
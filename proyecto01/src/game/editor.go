@@ -0,0 +1,289 @@
+package game
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+
+	"github.com/Krud3/InteligenciaArtificial/src/datatypes"
+	"github.com/Krud3/InteligenciaArtificial/src/i18n"
+	"github.com/Krud3/InteligenciaArtificial/src/utils"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// Snapshots kept to undo
+const editorHistorySize = 100
+
+// Brushes of the palette, in the same order as the keys 0-6
+var editorBrushes = []struct {
+	value int
+	name  string // Key of the name in the i18n catalogue
+}{
+	{0, "editor.road"}, {1, "editor.wall"}, {2, "editor.start"}, {3, "editor.medium"}, {4, "editor.heavy"}, {5, "editor.passenger"}, {6, "editor.goal"},
+}
+
+// Buttons of the editor, in the bar under the board
+var (
+	editorBarY          = MaxSize*TileSize + 4
+	fewerRowsButtonRect = image.Rect(236, editorBarY, 268, editorBarY+26)
+	moreRowsButtonRect  = image.Rect(272, editorBarY, 304, editorBarY+26)
+	fewerColsButtonRect = image.Rect(316, editorBarY, 348, editorBarY+26)
+	moreColsButtonRect  = image.Rect(352, editorBarY, 384, editorBarY+26)
+	undoButtonRect      = image.Rect(4, editorBarY+30, 64, editorBarY+56)
+	redoButtonRect      = image.Rect(68, editorBarY+30, 128, editorBarY+56)
+	saveButtonRect      = image.Rect(132, editorBarY+30, 192, editorBarY+56)
+	editorBackRect      = image.Rect(196, editorBarY+30, 256, editorBarY+56)
+)
+
+// Editor paints a map over the board and saves it into the map directory
+type Editor struct {
+	matrix   datatypes.Matrix
+	images   map[Tile]*ebiten.Image
+	brush    int
+	painting bool // A stroke started on the board and the button is still held
+	undo     []datatypes.Matrix
+	redo     []datatypes.Matrix
+	message  string
+	// Name of the last saved file, empty until something is saved
+	Saved string
+	Done  bool
+}
+
+// NewEditor starts editing a copy of matrix, or an empty road grid if it's nil
+func NewEditor(matrix datatypes.Matrix) (*Editor, error) {
+	scene, err := NewScene(nil)
+	if err != nil {
+		return nil, err
+	}
+	editor := &Editor{
+		images: scene.Images,
+		brush:  1,
+	}
+	if len(matrix) == 0 {
+		matrix = make(datatypes.Matrix, MaxSize)
+		for i := range matrix {
+			matrix[i] = make([]int, MaxSize)
+		}
+	}
+	editor.matrix = copyMatrix(matrix)
+	return editor, nil
+}
+
+func (e *Editor) Update() {
+	control := ebiten.IsKeyPressed(ebiten.KeyControl) || ebiten.IsKeyPressed(ebiten.KeyMeta)
+	for i := range editorBrushes {
+		if inpututil.IsKeyJustPressed(ebiten.Key0 + ebiten.Key(i)) {
+			e.brush = editorBrushes[i].value
+		}
+	}
+	switch {
+	case control && inpututil.IsKeyJustPressed(ebiten.KeyZ) && ebiten.IsKeyPressed(ebiten.KeyShift):
+		e.Redo()
+	case control && inpututil.IsKeyJustPressed(ebiten.KeyZ):
+		e.Undo()
+	case control && inpututil.IsKeyJustPressed(ebiten.KeyY):
+		e.Redo()
+	case control && inpututil.IsKeyJustPressed(ebiten.KeyS):
+		e.Save()
+	case inpututil.IsKeyJustPressed(ebiten.KeyEscape):
+		e.Done = true
+	}
+
+	cursor := image.Pt(ebiten.CursorPosition())
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		if _, _, onBoard := e.cellAt(cursor); onBoard {
+			// Each stroke is undone as a whole
+			e.pushUndo()
+			e.painting = true
+		}
+		for i := range editorBrushes {
+			if cursor.In(brushRect(i)) {
+				e.brush = editorBrushes[i].value
+			}
+		}
+		switch {
+		case cursor.In(fewerRowsButtonRect):
+			e.Resize(len(e.matrix)-1, len(e.matrix[0]))
+		case cursor.In(moreRowsButtonRect):
+			e.Resize(len(e.matrix)+1, len(e.matrix[0]))
+		case cursor.In(fewerColsButtonRect):
+			e.Resize(len(e.matrix), len(e.matrix[0])-1)
+		case cursor.In(moreColsButtonRect):
+			e.Resize(len(e.matrix), len(e.matrix[0])+1)
+		case cursor.In(undoButtonRect):
+			e.Undo()
+		case cursor.In(redoButtonRect):
+			e.Redo()
+		case cursor.In(saveButtonRect):
+			e.Save()
+		case cursor.In(editorBackRect):
+			e.Done = true
+		}
+	}
+	if !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		e.painting = false
+	}
+	if e.painting {
+		if row, column, onBoard := e.cellAt(cursor); onBoard {
+			e.paint(row, column)
+		}
+	}
+}
+
+// cellAt returns the cell under a point of the screen
+func (e *Editor) cellAt(point image.Point) (int, int, bool) {
+	if point.X < 0 || point.Y < 0 {
+		return 0, 0, false
+	}
+	row, column := point.Y/TileSize, point.X/TileSize
+	return row, column, row < len(e.matrix) && column < len(e.matrix[0])
+}
+
+// paint sets the brush on a cell, start, passenger and goal are unique so
+// placing one again moves it
+func (e *Editor) paint(row, column int) {
+	if e.brush == 2 || e.brush == 5 || e.brush == 6 {
+		for i := range e.matrix {
+			for j := range e.matrix[i] {
+				if e.matrix[i][j] == e.brush {
+					e.matrix[i][j] = 0
+				}
+			}
+		}
+	}
+	e.matrix[row][column] = e.brush
+}
+
+// Resize changes the size of the grid keeping the top left corner, new cells are road
+func (e *Editor) Resize(rows, columns int) {
+	if rows < 1 || columns < 1 || rows > MaxSize || columns > MaxSize {
+		return
+	}
+	e.pushUndo()
+	resized := make(datatypes.Matrix, rows)
+	for i := range resized {
+		resized[i] = make([]int, columns)
+		if i < len(e.matrix) {
+			copy(resized[i], e.matrix[i])
+		}
+	}
+	e.matrix = resized
+}
+
+func (e *Editor) pushUndo() {
+	e.undo = append(e.undo, copyMatrix(e.matrix))
+	if len(e.undo) > editorHistorySize {
+		e.undo = e.undo[1:]
+	}
+	e.redo = nil
+}
+
+func (e *Editor) Undo() {
+	if len(e.undo) == 0 {
+		return
+	}
+	e.redo = append(e.redo, e.matrix)
+	e.matrix = e.undo[len(e.undo)-1]
+	e.undo = e.undo[:len(e.undo)-1]
+}
+
+func (e *Editor) Redo() {
+	if len(e.redo) == 0 {
+		return
+	}
+	e.undo = append(e.undo, e.matrix)
+	e.matrix = e.redo[len(e.redo)-1]
+	e.redo = e.redo[:len(e.redo)-1]
+}
+
+// Save validates the map and writes it into the map directory with a new name
+func (e *Editor) Save() {
+	if err := utils.CheckMap(e.matrix); err != nil {
+		e.message = i18n.T("editor.cantSave", err)
+		return
+	}
+	name := ""
+	for i := 1; name == ""; i++ {
+		candidate := fmt.Sprintf("Editor%d.txt", i)
+		if !utils.MapExists(candidate) {
+			name = candidate
+		}
+	}
+	if _, err := utils.SaveMap(name, e.matrix); err != nil {
+		e.message = i18n.T("editor.cantSave", err)
+		return
+	}
+	e.Saved = name
+	e.message = i18n.T("editor.saved", name)
+}
+
+func (e *Editor) Draw(screen *ebiten.Image) {
+	screen.Fill(color.RGBA{30, 30, 30, 255})
+	for i, row := range e.matrix {
+		for j, value := range row {
+			e.drawTile(screen, value, j*TileSize, i*TileSize)
+		}
+	}
+
+	cursor := image.Pt(ebiten.CursorPosition())
+	if row, column, onBoard := e.cellAt(cursor); onBoard {
+		ebitenutil.DrawRect(screen, float64(column*TileSize), float64(row*TileSize), TileSize, TileSize, color.NRGBA{255, 255, 255, 60})
+	}
+
+	for i, brush := range editorBrushes {
+		rect := brushRect(i)
+		if brush.value == e.brush {
+			ebitenutil.DrawRect(screen, float64(rect.Min.X-2), float64(rect.Min.Y-2), float64(rect.Dx()+4), float64(rect.Dy()+4), color.RGBA{255, 255, 0, 255})
+		}
+		ebitenutil.DrawRect(screen, float64(rect.Min.X), float64(rect.Min.Y), float64(rect.Dx()), float64(rect.Dy()), editorBrushColor(brush.value))
+	}
+	for _, button := range []struct {
+		rect  image.Rectangle
+		label string
+	}{
+		{fewerRowsButtonRect, "R-"}, {moreRowsButtonRect, "R+"}, {fewerColsButtonRect, "C-"}, {moreColsButtonRect, "C+"},
+		{undoButtonRect, i18n.T("editor.undo")}, {redoButtonRect, i18n.T("editor.redo")}, {saveButtonRect, i18n.T("editor.save")}, {editorBackRect, i18n.T("editor.back")},
+	} {
+		ebitenutil.DrawRect(screen, float64(button.rect.Min.X), float64(button.rect.Min.Y), float64(button.rect.Dx()), float64(button.rect.Dy()), color.RGBA{60, 60, 160, 255})
+		ebitenutil.DebugPrintAt(screen, button.label, button.rect.Min.X+8, button.rect.Min.Y+5)
+	}
+	status := i18n.T("editor.status", len(e.matrix), len(e.matrix[0]), i18n.T(editorBrushes[e.brush].name))
+	ebitenutil.DebugPrintAt(screen, status, moreColsButtonRect.Max.X+10, moreColsButtonRect.Min.Y+5)
+	ebitenutil.DebugPrintAt(screen, e.message, editorBackRect.Max.X+10, editorBackRect.Min.Y+5)
+}
+
+// drawTile draws a cell the way the game shows it, start and passenger over the road
+func (e *Editor) drawTile(screen *ebiten.Image, value, x, y int) {
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(float64(x), float64(y))
+	switch Tile(value) {
+	case Car, Passenger:
+		screen.DrawImage(e.images[Road], op)
+		screen.DrawImage(e.images[Tile(value)], op)
+	default:
+		screen.DrawImage(e.images[Tile(value)], op)
+	}
+}
+
+func brushRect(index int) image.Rectangle {
+	return image.Rect(4+index*32, editorBarY, 32+index*32, editorBarY+26)
+}
+
+func editorBrushColor(value int) color.Color {
+	for rgba, paletteValue := range utils.DefaultPalette {
+		if paletteValue == value {
+			return rgba
+		}
+	}
+	return color.Black
+}
+
+func copyMatrix(matrix datatypes.Matrix) datatypes.Matrix {
+	copied := make(datatypes.Matrix, len(matrix))
+	for i, row := range matrix {
+		copied[i] = append([]int(nil), row...)
+	}
+	return copied
+}
@@ -1,10 +1,10 @@
 package entities
 
 import (
-	"log"
+	"math"
 
+	"github.com/Krud3/InteligenciaArtificial/src/datatypes"
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 )
 
 type Car struct {
@@ -15,20 +15,27 @@ type Car struct {
 	Image                    *ebiten.Image
 	frameCount               int
 	Delay                    int
+	Paused                   bool
+	// Direction of the last move as seen on screen, UP goes towards the first row
+	Heading datatypes.AgentAction
+	// Sprites of the car alone and carrying the passenger
+	emptyImage, fullImage *ebiten.Image
 }
 
 func (c *Car) Update() {
 	if c.Index >= len(c.Path) {
 		return // No more movement if the car reached the end of the path
 	}
+	if c.Paused {
+		return
+	}
 
 	c.frameCount++
 
 	if c.frameCount >= c.Delay {
 		// Move the car to the next position in the path
 		nextPos := c.Path[c.Index]
-		c.PosX = nextPos[1]
-		c.PosY = nextPos[0]
+		c.MoveTo(nextPos[1], nextPos[0])
 
 		c.Index++        // Move to the next path index
 		c.frameCount = 0 // Reset the frame counter
@@ -39,17 +46,94 @@ func (c *Car) Reset() {
 	c.PosX = c.InitialPosX
 	c.PosY = c.InitialPosY
 	c.Index = 0
+	c.Heading = datatypes.RIGHT
+}
+
+// MoveTo puts the car on a cell facing the way it moved
+func (c *Car) MoveTo(x, y int) {
+	switch {
+	case x > c.PosX:
+		c.Heading = datatypes.RIGHT
+	case x < c.PosX:
+		c.Heading = datatypes.LEFT
+	case y < c.PosY:
+		c.Heading = datatypes.UP
+	case y > c.PosY:
+		c.Heading = datatypes.DOWN
+	}
+	c.PosX = x
+	c.PosY = y
+}
+
+// DrawOptions places the car sprite on its cell, turned to its heading. The
+// sprite faces right, so going left flips it instead of turning it upside down
+func (c *Car) DrawOptions(tileSize int) *ebiten.DrawImageOptions {
+	op := &ebiten.DrawImageOptions{}
+	half := float64(c.Image.Bounds().Dx()) / 2
+	op.GeoM.Translate(-half, -half)
+	switch c.Heading {
+	case datatypes.LEFT:
+		op.GeoM.Scale(-1, 1)
+	case datatypes.UP:
+		op.GeoM.Rotate(-math.Pi / 2)
+	case datatypes.DOWN:
+		op.GeoM.Rotate(math.Pi / 2)
+	}
+	op.GeoM.Translate(half+float64(c.PosX*tileSize), half+float64(c.PosY*tileSize))
+	return op
 }
 
 func (c *Car) SetPath(path [][]int) {
 	c.Path = path
 }
 
-func NewCar(x, y int) *Car {
-	// Load the car image
-	carImage, _, err := ebitenutil.NewImageFromFile("./game/assets/images/moto-1-narvaez.png")
+// Seek moves the car to where it is after the first index steps of its path
+func (c *Car) Seek(index int) {
+	index = max(0, min(index, len(c.Path)))
+	c.Reset()
+	c.Index = index
+	c.frameCount = 0
+	if index == 0 {
+		return
+	}
+	// Come from the previous cell so the heading is the one of the last move
+	if index >= 2 {
+		c.PosX = c.Path[index-2][1]
+		c.PosY = c.Path[index-2][0]
+	}
+	c.MoveTo(c.Path[index-1][1], c.Path[index-1][0])
+}
+
+func (c *Car) StepForward() {
+	c.Seek(c.Index + 1)
+}
+
+func (c *Car) StepBack() {
+	c.Seek(c.Index - 1)
+}
+
+// Passed tells if the car already went through a cell, counting where it started
+func (c *Car) Passed(x, y int) bool {
+	if c.InitialPosX == x && c.InitialPosY == y {
+		return true
+	}
+	for _, position := range c.Path[:c.Index] {
+		if position[1] == x && position[0] == y {
+			return true
+		}
+	}
+	return false
+}
+
+func NewCar(x, y int) (*Car, error) {
+	// Load the car images, with and without the passenger
+	carImage, err := LoadImage("moto-1-narvaez.png")
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
+	}
+	carWithPassengerImage, err := LoadImage("moto-1-narvaez-girl.png")
+	if err != nil {
+		return nil, err
 	}
 	return &Car{
 		PosX:        x,
@@ -59,14 +143,17 @@ func NewCar(x, y int) *Car {
 		Path:        [][]int{},
 		Index:       0,
 		Image:       carImage,
+		emptyImage:  carImage,
+		fullImage:   carWithPassengerImage,
 		Delay:       30,
-	}
+		Heading:     datatypes.RIGHT,
+	}, nil
+}
+
+func (c *Car) SetImageWithoutPassenger() {
+	c.Image = c.emptyImage
 }
 
 func (c *Car) SetImageWithPassenger() {
-	carImage, _, err := ebitenutil.NewImageFromFile("./game/assets/images/moto-1-narvaez-girl.png")
-	if err != nil {
-		log.Fatal(err)
-	}
-	c.Image = carImage
+	c.Image = c.fullImage
 }
@@ -0,0 +1,30 @@
+package entities
+
+import (
+	"sync"
+
+	"github.com/Krud3/InteligenciaArtificial/src/game/assets"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Embedded images already turned into ebiten images, they're shared by every entity
+var (
+	loadedImages   = make(map[string]*ebiten.Image)
+	loadedImagesMu sync.Mutex
+)
+
+// LoadImage returns one of the embedded images, like "muro-1.png"
+func LoadImage(name string) (*ebiten.Image, error) {
+	loadedImagesMu.Lock()
+	defer loadedImagesMu.Unlock()
+	if img, found := loadedImages[name]; found {
+		return img, nil
+	}
+	decoded, err := assets.Decode(name)
+	if err != nil {
+		return nil, err
+	}
+	img := ebiten.NewImageFromImage(decoded)
+	loadedImages[name] = img
+	return img, nil
+}
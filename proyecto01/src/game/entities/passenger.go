@@ -1,10 +1,7 @@
 package entities
 
 import (
-	"log"
-
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 )
 
 type Passenger struct {
@@ -13,17 +10,17 @@ type Passenger struct {
 	Image                    *ebiten.Image
 }
 
-func NewPassenger(x, y int) *Passenger {
+func NewPassenger(x, y int) (*Passenger, error) {
 
 	// Load the passenger image
-	passengerImage, _, err := ebitenutil.NewImageFromFile("./game/assets/images/girl.png")
+	passengerImage, err := LoadImage("girl.png")
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 
 	return &Passenger{
 		PosX:  x,
 		PosY:  y,
 		Image: passengerImage,
-	}
+	}, nil
 }
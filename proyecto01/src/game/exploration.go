@@ -0,0 +1,182 @@
+package game
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+
+	"github.com/Krud3/InteligenciaArtificial/src/datatypes"
+	"github.com/Krud3/InteligenciaArtificial/src/i18n"
+	"github.com/Krud3/InteligenciaArtificial/src/searchAlgorithms"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// Expansions per second the playback can run at
+var explorationSpeeds = []float64{1, 2, 5, 10, 20, 50, 100}
+
+const defaultExplorationSpeed = 3
+
+var (
+	earlyExpansionColor = color.NRGBA{R: 255, G: 230, B: 80, A: 150}
+	lateExpansionColor  = color.NRGBA{R: 40, G: 60, B: 200, A: 150}
+	frontierColor       = color.NRGBA{R: 255, G: 0, B: 200, A: 255}
+)
+
+// Buttons of the playback, in the bar under the board
+var (
+	slowerButtonRect = image.Rect(140, MaxSize*TileSize+10, 180, MaxSize*TileSize+50)
+	fasterButtonRect = image.Rect(190, MaxSize*TileSize+10, 230, MaxSize*TileSize+50)
+	skipButtonRect   = image.Rect(240, MaxSize*TileSize+10, 300, MaxSize*TileSize+50)
+)
+
+// SearchPlayback animates the recorded events of a search over the board,
+// before the car drives the solution
+type SearchPlayback struct {
+	trace      *searchAlgorithms.SearchTrace
+	next       int // Index of the next event to apply
+	expansions int // Expand events already applied
+	total      int
+	speedIndex int
+	pending    float64 // Expansions owed since the last applied one
+	explored   map[datatypes.BoardCoordinate]int
+	frontier   map[int]searchAlgorithms.SearchNode // Nodes waiting in the frontier by ID
+	values     map[datatypes.BoardCoordinate]searchAlgorithms.SearchNode
+	Finished   bool
+}
+
+func NewSearchPlayback(trace *searchAlgorithms.SearchTrace) *SearchPlayback {
+	return &SearchPlayback{
+		trace:      trace,
+		total:      trace.Expansions(),
+		speedIndex: defaultExplorationSpeed,
+		explored:   make(map[datatypes.BoardCoordinate]int),
+		frontier:   make(map[int]searchAlgorithms.SearchNode),
+		values:     make(map[datatypes.BoardCoordinate]searchAlgorithms.SearchNode),
+		Finished:   len(trace.Events) == 0,
+	}
+}
+
+func (p *SearchPlayback) Update() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEqual) || inpututil.IsKeyJustPressed(ebiten.KeyNumpadAdd) {
+		p.Faster()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyMinus) || inpututil.IsKeyJustPressed(ebiten.KeyNumpadSubtract) {
+		p.Slower()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyS) {
+		p.Skip()
+	}
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		cursor := image.Pt(ebiten.CursorPosition())
+		switch {
+		case cursor.In(slowerButtonRect):
+			p.Slower()
+		case cursor.In(fasterButtonRect):
+			p.Faster()
+		case cursor.In(skipButtonRect):
+			p.Skip()
+		}
+	}
+
+	p.pending += explorationSpeeds[p.speedIndex] / float64(ebiten.TPS())
+	for p.pending >= 1 && !p.Finished {
+		p.step()
+		p.pending--
+	}
+}
+
+// step applies the events up to the next expansion
+func (p *SearchPlayback) step() {
+	for p.next < len(p.trace.Events) {
+		event := p.trace.Events[p.next]
+		p.next++
+		switch event.Kind {
+		case searchAlgorithms.GenerateEvent:
+			if event.Node.Restarts() {
+				// The search cleared its frontier to start over from the passenger
+				for id, node := range p.frontier {
+					if !node.HasPickedUpPassenger {
+						delete(p.frontier, id)
+					}
+				}
+			}
+			p.frontier[event.Node.ID] = event.Node
+			p.values[event.Node.Position] = event.Node
+		case searchAlgorithms.GoalTestEvent, searchAlgorithms.PruneEvent:
+			// Taken out of the frontier, or discarded before entering it
+			delete(p.frontier, event.Node.ID)
+		case searchAlgorithms.ExpandEvent:
+			delete(p.frontier, event.Node.ID)
+			p.explored[event.Node.Position] = p.expansions
+			p.values[event.Node.Position] = event.Node
+			p.expansions++
+			return
+		}
+	}
+	p.Finished = true
+}
+
+// Skip shows the whole exploration at once
+func (p *SearchPlayback) Skip() {
+	for !p.Finished {
+		p.step()
+	}
+}
+
+func (p *SearchPlayback) Faster() {
+	p.speedIndex = min(p.speedIndex+1, len(explorationSpeeds)-1)
+}
+
+func (p *SearchPlayback) Slower() {
+	p.speedIndex = max(p.speedIndex-1, 0)
+}
+
+// Draw tints the explored cells by expansion order and outlines the frontier
+func (p *SearchPlayback) Draw(screen *ebiten.Image) {
+	for position, order := range p.explored {
+		t := float64(order) / float64(max(p.total-1, 1))
+		ebitenutil.DrawRect(screen, float64(position.Y*TileSize), float64(position.X*TileSize), TileSize, TileSize, lerpColor(earlyExpansionColor, lateExpansionColor, t))
+	}
+	for _, node := range p.frontier {
+		x, y := float64(node.Position.Y*TileSize), float64(node.Position.X*TileSize)
+		ebitenutil.DrawRect(screen, x, y, TileSize, 3, frontierColor)
+		ebitenutil.DrawRect(screen, x, y+TileSize-3, TileSize, 3, frontierColor)
+		ebitenutil.DrawRect(screen, x, y, 3, TileSize, frontierColor)
+		ebitenutil.DrawRect(screen, x+TileSize-3, y, 3, TileSize, frontierColor)
+	}
+}
+
+// DrawHover shows the values of the last node seen on the cell under the cursor
+func (p *SearchPlayback) DrawHover(screen *ebiten.Image, camera *Camera) {
+	cursorX, cursorY := ebiten.CursorPosition()
+	if row, column, ok := camera.CellAt(image.Pt(cursorX, cursorY)); ok {
+		position := datatypes.BoardCoordinate{X: row, Y: column}
+		if node, found := p.values[position]; found {
+			label := fmt.Sprintf("g=%.0f h=%.0f f=%.0f", node.G, node.H, node.F)
+			ebitenutil.DrawRect(screen, float64(cursorX+12), float64(cursorY+12), float64(len(label)*6+8), 20, color.RGBA{0, 0, 0, 200})
+			ebitenutil.DebugPrintAt(screen, label, cursorX+16, cursorY+14)
+		}
+	}
+}
+
+// DrawControls draws the speed buttons in the bar under the board
+func (p *SearchPlayback) DrawControls(screen *ebiten.Image) {
+	for _, button := range []struct {
+		rect  image.Rectangle
+		label string
+	}{{slowerButtonRect, " -"}, {fasterButtonRect, " +"}, {skipButtonRect, i18n.T("game.skip")}} {
+		ebitenutil.DrawRect(screen, float64(button.rect.Min.X), float64(button.rect.Min.Y), float64(button.rect.Dx()), float64(button.rect.Dy()), color.RGBA{60, 60, 160, 255})
+		ebitenutil.DebugPrintAt(screen, button.label, button.rect.Min.X+10, button.rect.Min.Y+12)
+	}
+	status := i18n.T("game.expanded", p.expansions, p.total, explorationSpeeds[p.speedIndex])
+	ebitenutil.DebugPrintAt(screen, status, skipButtonRect.Max.X+10, skipButtonRect.Min.Y+12)
+}
+
+func lerpColor(from, to color.NRGBA, t float64) color.NRGBA {
+	lerp := func(a, b uint8) uint8 {
+		return uint8(float64(a) + (float64(b)-float64(a))*t)
+	}
+	return color.NRGBA{R: lerp(from.R, to.R), G: lerp(from.G, to.G), B: lerp(from.B, to.B), A: lerp(from.A, to.A)}
+}
@@ -1,18 +1,21 @@
 package game
 
 import (
-	"fmt"
 	"image"
 	"image/color"
 	"io"
 	"log"
 	"os"
 	"path/filepath"
-	"time"
+	"strings"
 
 	"github.com/Krud3/InteligenciaArtificial/src/datatypes"
+	"github.com/Krud3/InteligenciaArtificial/src/i18n"
+	"github.com/Krud3/InteligenciaArtificial/src/input"
 	"github.com/Krud3/InteligenciaArtificial/src/searchAlgorithms"
+	"github.com/Krud3/InteligenciaArtificial/src/session"
 	"github.com/Krud3/InteligenciaArtificial/src/utils"
+	"github.com/Krud3/InteligenciaArtificial/src/visuals"
 
 	"github.com/Krud3/InteligenciaArtificial/src/game/entities"
 	"github.com/hajimehoshi/ebiten/v2"
@@ -24,8 +27,6 @@ type GameState int
 
 type AlgorithmType int
 
-type AreaOfKeyEvents int
-
 type Game struct {
 	state                  GameState
 	scene                  *Scene
@@ -33,22 +34,53 @@ type Game struct {
 	passenger              *entities.Passenger
 	selectedFileIndex      int
 	files                  []string
-	frameCount             int
 	algorithms             []string
 	algorithmType          AlgorithmType
 	selectedAlgorithmIndex int
-	selectedBox            AreaOfKeyEvents
 	nodesExpanded          int
 	treeDepth              int
 	computationTime        float64
 	solutionCost           float64
+	searchStatus           datatypes.SearchStatus
 	titleImage             *ebiten.Image
+	exploration            *SearchPlayback
+	carControls            *CarControls
+	directions             *DirectionsPanel
+	search                 *BackgroundSearch
+	editor                 *Editor
+	comparison             *Comparison
+	driving                *Driving
+	menu                   *Menu
+	// The board is drawn into world and the camera shows it on the screen
+	world       *ebiten.Image
+	camera      *Camera
+	screenWidth int
+	endScreen   *EndScreen
+	trip        *TripSummary
+	history     *HistoryScreen
+	// Map of the scene, as it's listed in the menu
+	currentMap string
+	// Speed of the car and size of the window, kept for the next session
+	carSpeed                  int
+	windowWidth, windowHeight int
+	// Language picked in the menu, empty while the locale decides
+	language string
+	// Algorithm that found the path the car follows
+	currentAlgorithm string
+	// Algorithms picked with Ctrl+click to run side by side
+	comparedAlgorithms map[string]bool
 }
 
 const (
 	MenuState GameState = iota
 	PlayingState
 	EndState
+	EditorState
+	CompareState
+	DriveState
+	SearchingState
+	TripState
+	HistoryState
 )
 
 const (
@@ -56,41 +88,46 @@ const (
 	UninformedAlgorithm
 )
 
-const (
-	LeftBox AreaOfKeyEvents = iota
-	RightBox
-)
-
-const (
-	verticalUploadPhase   int = 0
-	horizontalUploadPhase int = -375
-)
-
-const (
-	verticalSelectAlPhase   int = 0
-	horizontalSelectAlPhase int = 380
+// Buttons of the game, in the bar under the board
+var (
+	gameBackButtonRect = image.Rect(MaxSize*TileSize-120, MaxSize*TileSize+10, MaxSize*TileSize, MaxSize*TileSize+50)
+	statsButtonRect    = image.Rect(0, MaxSize*TileSize+10, 120, MaxSize*TileSize+50)
 )
 
 var (
-	informedAlgorithms   []string = []string{"Avaro", "A*"}
-	uninformedAlgorithms []string = []string{"Breadth First Algorithm", "DepthSearch", "Uniform Cost Search"}
+	informedAlgorithms   []string = []string{searchAlgorithms.MiserName, searchAlgorithms.AStarName}
+	uninformedAlgorithms []string = []string{searchAlgorithms.BreadthFirstName, searchAlgorithms.DepthName, searchAlgorithms.UniformCostName}
 )
 
 var Matrix datatypes.ScannedMatrix
 
-func NewGame(matrixFileName string) (*Game, error) {
-	Matrix, _ = utils.GetMatrix(matrixFileName)
+// NewGame starts on the menu with the choices of the last session
+func NewGame(matrixFileName string, settings session.Settings) (*Game, error) {
+	var err error
+	Matrix, err = utils.GetMatrix(matrixFileName)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create the scene
-	scene := NewScene(Matrix.Matrix)
+	scene, err := NewScene(Matrix.Matrix)
+	if err != nil {
+		return nil, err
+	}
 
-	car := entities.NewCar(scene.CarPosX, scene.CarPosY) // Create the car
+	car, err := entities.NewCar(scene.CarPosX, scene.CarPosY) // Create the car
+	if err != nil {
+		return nil, err
+	}
 
-	passenger := entities.NewPassenger(scene.PassengerPosX, scene.PassengerPosY) // Create the passenger
+	passenger, err := entities.NewPassenger(scene.PassengerPosX, scene.PassengerPosY) // Create the passenger
+	if err != nil {
+		return nil, err
+	}
 
-	titleImage, _, err := ebitenutil.NewImageFromFile("./game/assets/images/title.png")
+	titleImage, err := entities.LoadImage("title.png")
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 
 	game := &Game{
@@ -102,20 +139,57 @@ func NewGame(matrixFileName string) (*Game, error) {
 		algorithms:             informedAlgorithms,
 		algorithmType:          InformedAlgorithm,
 		selectedAlgorithmIndex: -1,
-		selectedBox:            LeftBox,
 		titleImage:             titleImage,
+		screenWidth:            MaxSize * TileSize,
+		currentMap:             matrixFileName,
+		carSpeed:               defaultCarSpeed,
+		comparedAlgorithms:     make(map[string]bool),
+	}
+	game.files, err = utils.ListMaps() // List the embedded maps and the ones in the map directory
+	if err != nil {
+		return nil, err
 	}
+	game.applySettings(settings)
 
-	game.files = game.ListMatrixFiles() // List the files in the 'battery' folder
+	game.world, game.camera = newBoardView(scene)
+	game.menu = newMenu(game)
+	game.endScreen = newEndScreen(game)
+	game.history = newHistoryScreen(game)
 
 	return game, nil
 }
 
+// newBoardView returns the image the board is drawn into and a camera over it
+func newBoardView(scene *Scene) (*ebiten.Image, *Camera) {
+	width, height := scene.Size()
+	return ebiten.NewImage(max(width, 1), max(height, 1)), NewCamera(width, height)
+}
+
+// Layout keeps the height of the screen. The screens with a board use the
+// whole width of the window to show more of it, the rest keep their layout
 func (g *Game) Layout(outsideWidth, outsideHeight int) (screenWidth, screenHeight int) {
-	return MaxSize * TileSize, (MaxSize * TileSize) + TileSize
+	screenWidth, screenHeight = MaxSize*TileSize, (MaxSize*TileSize)+TileSize
+	if outsideWidth > 0 && outsideHeight > 0 {
+		g.windowWidth, g.windowHeight = outsideWidth, outsideHeight
+	}
+	if g.showsBoard() && outsideHeight > 0 {
+		screenWidth = max(screenWidth, outsideWidth*screenHeight/outsideHeight)
+	}
+	g.screenWidth = screenWidth
+	return screenWidth, screenHeight
+}
+
+func (g *Game) showsBoard() bool {
+	return g.state == PlayingState || g.state == SearchingState || g.state == DriveState || g.state == TripState
+}
+
+// boardViewport is the part of the screen over the bar of buttons
+func (g *Game) boardViewport() image.Rectangle {
+	return image.Rect(0, 0, g.screenWidth, MaxSize*TileSize)
 }
 
 func (g *Game) Draw(screen *ebiten.Image) {
+	screen.Fill(visuals.Background)
 	switch g.state {
 	case MenuState:
 		g.DrawMenu(screen)
@@ -123,142 +197,76 @@ func (g *Game) Draw(screen *ebiten.Image) {
 		g.DrawGame(screen)
 	case EndState:
 		g.DrawEndScreen(screen)
+	case EditorState:
+		g.editor.Draw(screen)
+	case CompareState:
+		g.comparison.Draw(screen)
+	case DriveState:
+		g.driving.Draw(screen)
+	case SearchingState:
+		g.world.Clear()
+		g.scene.Draw(g.world)
+		g.camera.Draw(screen, g.world)
+		g.search.Draw(screen)
+	case TripState:
+		g.DrawTrip(screen)
+	case HistoryState:
+		g.history.Draw(screen)
 	}
 }
 
 func (g *Game) DrawMenu(screen *ebiten.Image) {
-	// Draw the title image at the top-center of the screen
-	screenWidth, _ := ebiten.WindowSize()
-	titleImageWidth, _ := g.titleImage.Size()
-
-	// Calculate the position to center the title image
-	x := (screenWidth - titleImageWidth) / 2
-	y := 50 // You can adjust this for vertical positioning
-
-	// Draw the title image
-	op := &ebiten.DrawImageOptions{}
-	op.GeoM.Translate(float64(x), float64(y))
-	screen.DrawImage(g.titleImage, op)
-
-	// Dibujar el botón de inicio
-	startButtonRect := image.Rect(((MaxSize*TileSize)/2 - 300 + horizontalSelectAlPhase), 550+verticalSelectAlPhase, ((MaxSize*TileSize)/2 - 100 + horizontalSelectAlPhase), 650+verticalSelectAlPhase)
-	ebitenutil.DrawRect(screen, float64(startButtonRect.Min.X), float64(startButtonRect.Min.Y), float64(startButtonRect.Dx()), float64(startButtonRect.Dy()), color.RGBA{0, 160, 0, 255})
-	ebitenutil.DrawRect(screen, float64(startButtonRect.Min.X)+5, float64(startButtonRect.Min.Y)+5, float64(startButtonRect.Dx())-10, float64(startButtonRect.Dy())-10, color.RGBA{0, 140, 0, 255})
-	ebitenutil.DebugPrintAt(screen, "Start Game", ((MaxSize*TileSize)/2-300)+65+horizontalSelectAlPhase, 592+verticalSelectAlPhase)
-
-	// Dibujar el botón para subir la matriz
-	uploadButtonRect := image.Rect((MaxSize*TileSize)/2+90+horizontalUploadPhase, (MaxSize*TileSize)/2-70+verticalUploadPhase, (MaxSize*TileSize)/2+310+horizontalUploadPhase, ((MaxSize*TileSize)/4*2)-20+verticalUploadPhase)
-	ebitenutil.DrawRect(screen, float64(uploadButtonRect.Min.X), float64(uploadButtonRect.Min.Y), float64(uploadButtonRect.Dx()), float64(uploadButtonRect.Dy()), color.RGBA{0, 50, 255, 255})
-	ebitenutil.DrawRect(screen, float64(uploadButtonRect.Min.X)+5, float64(uploadButtonRect.Min.Y)+5, float64(uploadButtonRect.Dx())-10, float64(uploadButtonRect.Dy())-10, color.RGBA{0, 35, 240, 255})
-	ebitenutil.DebugPrintAt(screen, "Upload Matrix", ((MaxSize*TileSize)/2+90)+65+horizontalUploadPhase, ((MaxSize*TileSize)/4*2)-52+verticalUploadPhase)
-	g.DrawFiles(screen)
-
-	// Dibujar el botón de búsqueda informada
-	informedSearchButtonRect := image.Rect((MaxSize*TileSize)/2-300+horizontalSelectAlPhase, 250+verticalSelectAlPhase, (MaxSize*TileSize)/2-100+horizontalSelectAlPhase, 300+verticalSelectAlPhase)
-	ebitenutil.DrawRect(screen, float64(informedSearchButtonRect.Min.X), float64(informedSearchButtonRect.Min.Y), float64(informedSearchButtonRect.Dx()), float64(informedSearchButtonRect.Dy()), color.RGBA{235, 130, 0, 255})
-	ebitenutil.DrawRect(screen, float64(informedSearchButtonRect.Min.X)+5, float64(informedSearchButtonRect.Min.Y)+5, float64(informedSearchButtonRect.Dx())-10, float64(informedSearchButtonRect.Dy())-10, color.RGBA{220, 115, 0, 255})
-	ebitenutil.DebugPrintAt(screen, "Informed Search", ((MaxSize*TileSize)/2-300)+50+horizontalSelectAlPhase, 267+verticalSelectAlPhase)
-
-	// Dibujar el botón de búsqueda no informada
-	unInformedSearchButtonRect := image.Rect(((MaxSize*TileSize)/2 - 300 + horizontalSelectAlPhase), 300+verticalSelectAlPhase, ((MaxSize*TileSize)/2 - 100 + horizontalSelectAlPhase), 350+verticalSelectAlPhase)
-	ebitenutil.DrawRect(screen, float64(unInformedSearchButtonRect.Min.X), float64(unInformedSearchButtonRect.Min.Y), float64(unInformedSearchButtonRect.Dx()), float64(unInformedSearchButtonRect.Dy()), color.RGBA{235, 130, 0, 255})
-	ebitenutil.DrawRect(screen, float64(unInformedSearchButtonRect.Min.X)+5, float64(unInformedSearchButtonRect.Min.Y)+5, float64(unInformedSearchButtonRect.Dx())-10, float64(unInformedSearchButtonRect.Dy())-10, color.RGBA{220, 115, 0, 255})
-	ebitenutil.DebugPrintAt(screen, "Uninformed Search", ((MaxSize*TileSize)/2-300)+45+horizontalSelectAlPhase, 317+verticalSelectAlPhase)
-
-	g.DrawAlgorithms(screen)
-}
-
-func (g *Game) DrawFiles(screen *ebiten.Image) {
-	// Dibujar los archivos disponibles en la carpeta 'battery'
-	y := ((MaxSize * TileSize) / 2) + verticalUploadPhase
-	ebitenutil.DrawRect(screen, float64((MaxSize*TileSize)/2+100-10+horizontalUploadPhase), float64(y-20), 220, 20, color.RGBA{100, 100, 100, 255})
-	for i, file := range g.files {
-		text := file
-		if g.selectedFileIndex == i {
-			text = "> " + file // Añadir un indicador para mostrar el archivo seleccionado
-		}
-		ebitenutil.DrawRect(screen, float64((MaxSize*TileSize)/2+100-10+horizontalUploadPhase), float64(y), 220, 20, color.RGBA{100, 100, 100, 255})
-		ebitenutil.DebugPrintAt(screen, text, ((MaxSize*TileSize)/2 + 100 + horizontalUploadPhase), y)
-		y += 20
-	}
-	ebitenutil.DrawRect(screen, float64((MaxSize*TileSize)/2+100-10+horizontalUploadPhase), float64(y), 220, 20, color.RGBA{100, 100, 100, 255})
-}
-
-func (g *Game) DrawAlgorithms(screen *ebiten.Image) {
-	// Dibujar los archivos disponibles en la carpeta 'battery'
-	y := 350 + verticalSelectAlPhase + 20
-	ebitenutil.DrawRect(screen, float64((MaxSize*TileSize)/2-300+horizontalSelectAlPhase), float64(y-20), 200, 20*6, color.RGBA{100, 100, 100, 255})
-	for i, algorithm := range g.algorithms {
-		text := algorithm
-		if g.selectedAlgorithmIndex == i {
-			text = "> " + algorithm // Añadir un indicador para mostrar el archivo seleccionado
-		}
-		ebitenutil.DebugPrintAt(screen, text, ((MaxSize*TileSize)/2 - 300 + 10 + horizontalSelectAlPhase), y)
-		y += 20
-	}
+	g.menu.sync(g)
+	g.menu.playground.Draw(screen)
 }
 
 func (g *Game) DrawGame(screen *ebiten.Image) {
-	g.scene.Draw(screen)
+	g.world.Clear()
+	g.scene.Draw(g.world)
+	if g.exploration != nil {
+		g.exploration.Draw(g.world)
+	}
 
 	// Draw the car on top of the scene
-	carOp := &ebiten.DrawImageOptions{}
-	carOp.GeoM.Translate(float64(g.car.PosX*TileSize), float64(g.car.PosY*TileSize))
-	screen.DrawImage(g.car.Image, carOp)
+	g.world.DrawImage(g.car.Image, g.car.DrawOptions(TileSize))
 
 	// Optionally draw the passenger if it's still present
 	if g.passenger != nil {
 		passengerOp := &ebiten.DrawImageOptions{}
 		passengerOp.GeoM.Translate(float64(g.passenger.PosX*TileSize), float64(g.passenger.PosY*TileSize))
-		screen.DrawImage(g.passenger.Image, passengerOp)
-	}
-
-	// Render the "Back to Menu" button in the upper-right corner
-	backButtonWidth := 120
-	backButtonHeight := 40
-	screenWidth, _ := ebiten.WindowSize()
-	// Coordinates for the back button
-	backButtonX := screenWidth - backButtonWidth
-	backButtonY := MaxSize*TileSize + 10
-	// Draw the button (a rectangle)
-	ebitenutil.DrawRect(screen, float64(backButtonX), float64(backButtonY), float64(backButtonWidth), float64(backButtonHeight), color.RGBA{255, 0, 0, 255})
-	// Add text to the button
-	ebitenutil.DebugPrintAt(screen, "Back to Menu", backButtonX+20, backButtonY+10)
-
-	// Render the "Back to Menu" button in the upper-right corner
-	statsButtonWidth := 120
-	statsButtonHeight := 40
-	// Coordinates for the back button
-	statsButtonX := 0
-	statsButtonY := MaxSize*TileSize + 10
-	// Draw the button (a rectangle)
-	ebitenutil.DrawRect(screen, float64(statsButtonX), float64(statsButtonY), float64(statsButtonWidth), float64(statsButtonHeight), color.RGBA{125, 125, 125, 255})
-	// Add text to the button
-	ebitenutil.DebugPrintAt(screen, "Game Stats", statsButtonX+20, statsButtonY+10)
-}
-
-func (g *Game) DrawEndScreen(screen *ebiten.Image) {
-	ebitenutil.DebugPrintAt(screen, "Algorithm Execution Report", 50, 50)
-	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Nodes Expanded: %d", g.nodesExpanded), 50, 80)
-	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Tree Depth: %d", g.treeDepth), 50, 110)
-	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Computation Time: %.2f seconds", g.computationTime), 50, 140)
+		g.world.DrawImage(g.passenger.Image, passengerOp)
+	}
+	g.camera.Draw(screen, g.world)
 
-	// Only display the solution cost if applicable
-	if g.solutionCost > 0 {
-		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Solution Cost: %.2f", g.solutionCost), 50, 170)
+	if g.exploration != nil {
+		g.exploration.DrawHover(screen, g.camera)
+		if !g.exploration.Finished {
+			g.exploration.DrawControls(screen)
+		}
+	}
+	if (g.exploration == nil || g.exploration.Finished) && g.carControls != nil {
+		g.carControls.Draw(screen)
+		g.directions.Draw(screen, g.car.Index)
 	}
 
-	// Add a button to return to the menu
-	backButtonRect := image.Rect(50, 550, 200, 600)
-	ebitenutil.DrawRect(screen, float64(backButtonRect.Min.X), float64(backButtonRect.Min.Y), float64(backButtonRect.Dx()), float64(backButtonRect.Dy()), color.RGBA{0, 160, 0, 255})
-	ebitenutil.DebugPrintAt(screen, "Return to Menu", 70, 570)
+	// Render the "Back to Menu" and "Game Stats" buttons under the board
+	ebitenutil.DrawRect(screen, float64(gameBackButtonRect.Min.X), float64(gameBackButtonRect.Min.Y), float64(gameBackButtonRect.Dx()), float64(gameBackButtonRect.Dy()), color.RGBA{255, 0, 0, 255})
+	ebitenutil.DebugPrintAt(screen, i18n.T("common.backToMenu"), gameBackButtonRect.Min.X+20, gameBackButtonRect.Min.Y+10)
+	ebitenutil.DrawRect(screen, float64(statsButtonRect.Min.X), float64(statsButtonRect.Min.Y), float64(statsButtonRect.Dx()), float64(statsButtonRect.Dy()), color.RGBA{125, 125, 125, 255})
+	ebitenutil.DebugPrintAt(screen, i18n.T("game.stats"), statsButtonRect.Min.X+20, statsButtonRect.Min.Y+10)
+}
 
-	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
-		x, y := ebiten.CursorPosition()
-		if x >= 50 && x <= 200 && y >= 550 && y <= 600 {
-			g.state = MenuState // Return to the menu
-		}
+func (g *Game) DrawEndScreen(screen *ebiten.Image) {
+	g.endScreen.playground.Draw(screen)
+}
+
+func (g *Game) UpdateEndScreen() {
+	if input.Pressed(ebiten.KeyEscape) {
+		g.state = MenuState
+		return
 	}
+	g.endScreen.sync(g)
+	g.endScreen.playground.Update()
 }
 
 func (g *Game) Update() error {
@@ -268,215 +276,216 @@ func (g *Game) Update() error {
 		g.UpdateMenu()
 	case PlayingState:
 		g.UpdateGame()
+	case EndState:
+		g.UpdateEndScreen()
+	case EditorState:
+		g.UpdateEditor()
+	case CompareState:
+		g.comparison.Update()
+		if g.comparison.Done {
+			g.state = MenuState
+		}
+	case SearchingState:
+		g.camera.SetViewport(g.boardViewport())
+		g.camera.Update(nil)
+		g.UpdateSearching()
+	case DriveState:
+		g.driving.camera.SetViewport(g.boardViewport())
+		g.driving.Update()
+		if g.driving.Done {
+			g.state = MenuState
+		}
+	case TripState:
+		g.UpdateTrip()
+	case HistoryState:
+		g.UpdateHistory()
 	}
 	return nil
 }
 
 func (g *Game) UpdateMenu() {
-	const keyPressDelay = 8
-	g.frameCount++
-
-	if g.frameCount >= keyPressDelay {
-		g.frameCount = 0
-
-		// Navigate down the file list
-		if ebiten.IsKeyPressed(ebiten.KeyArrowDown) {
-			if g.selectedFileIndex < len(g.files)-1 && g.selectedBox == LeftBox {
-				g.selectedFileIndex++
-			}
-			if g.selectedAlgorithmIndex < len(g.algorithms)-1 && g.selectedBox == RightBox {
-				g.selectedAlgorithmIndex++
-			}
-		}
-
-		// Navigate up the file list
-		if ebiten.IsKeyPressed(ebiten.KeyArrowUp) {
-			if g.selectedFileIndex == -1 && len(g.files) > 0 && g.selectedBox == LeftBox {
-				g.selectedFileIndex = 1
-			}
-			if g.selectedFileIndex > 0 && g.selectedBox == LeftBox {
-				g.selectedFileIndex--
-			}
-
-			if g.selectedAlgorithmIndex == -1 && len(g.algorithms) > 0 && g.selectedBox == RightBox {
-				g.selectedAlgorithmIndex = 1
-			}
-			if g.selectedAlgorithmIndex > 0 && g.selectedBox == RightBox {
-				g.selectedAlgorithmIndex--
-			}
-		}
-
-		if ebiten.IsKeyPressed(ebiten.KeyArrowLeft) {
-			if g.selectedFileIndex == -1 && len(g.files) > 0 {
-				g.selectedFileIndex = 0
-			}
-			if g.selectedBox != LeftBox {
-				g.selectedBox = LeftBox
-			}
-			print("LeftBox")
-
-		}
-
-		if ebiten.IsKeyPressed(ebiten.KeyArrowRight) {
-			if g.selectedAlgorithmIndex == -1 && len(g.algorithms) > 0 {
-				g.selectedAlgorithmIndex = 0
-			}
-			if g.selectedBox != RightBox {
-				g.selectedBox = RightBox
-			}
-			print("RightBox")
-
-		}
-	}
-
-	// Confirmar la selección del archivo con Enter
-	if ebiten.IsKeyPressed(ebiten.KeyEnter) && g.selectedFileIndex >= 0 {
-		selectedFile := g.files[g.selectedFileIndex]
-		g.SetScene("../battery/" + selectedFile) // Cargar la nueva escena con el archivo seleccionado
-		g.state = PlayingState                   // Cambiar el estado al de juego
-		g.SetCarPath(g.algorithms[g.selectedAlgorithmIndex])
+	g.menu.sync(g)
+	if g.menu.playground.Update() {
+		return
 	}
 
-	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
-		x, y := ebiten.CursorPosition()
-
-		// Check if any file was clicked
-		fileStartY := ((MaxSize * TileSize) / 4 * 2) + verticalUploadPhase // Starting Y position of the first file in the list
-		fileHeight := 20                                                   // Height of each file item
-		for i, file := range g.files {
-			fileY := fileStartY + i*fileHeight
-			if x >= (MaxSize*TileSize)/2+100+horizontalUploadPhase && x <= (MaxSize*TileSize)/2+300+horizontalUploadPhase && y >= fileY && y <= fileY+fileHeight {
-				g.selectedFileIndex = i // Set the selected file based on the click
-				print("File selected:", file)
-			}
-		}
-
-		// Check if any algorithm was clicked
-		algorithmStartY := 350 + verticalSelectAlPhase + 20 // Starting Y position of the first file in the list
-		algorithmHeight := 20                               // Height of each file item
-		for i, algorithm := range g.algorithms {
-			algorithmY := algorithmStartY + i*algorithmHeight
-			if x >= ((MaxSize*TileSize)/2-300+10+horizontalSelectAlPhase) && x <= ((MaxSize*TileSize)/2-100+10+horizontalSelectAlPhase) && y >= algorithmY && y <= algorithmY+algorithmHeight {
-				g.selectedAlgorithmIndex = i // Set the selected file based on the click
-				print("File selected:", algorithm)
-			}
-		}
-
-		// Verificar si se presionó el botón "Start Game"
-		if x >= ((MaxSize*TileSize)/2-300+horizontalSelectAlPhase) && x <= ((MaxSize*TileSize)/2-100+horizontalSelectAlPhase) && y >= 550+verticalSelectAlPhase && y <= 650+verticalSelectAlPhase {
-			if g.selectedFileIndex >= 0 {
-				selectedFile := g.files[g.selectedFileIndex]
-				g.SetScene("../battery/" + selectedFile)
-				g.state = PlayingState
-				Matrix, _ = utils.GetMatrix("../battery/" + selectedFile)
-				g.SetCarPath(g.algorithms[g.selectedAlgorithmIndex])
-				print("Start Game")
-			}
-		}
-
-		// Verificar si se presionó el botón "Upload Matrix"
-		if x >= (MaxSize*TileSize)/2+90+horizontalUploadPhase && x <= (MaxSize*TileSize)/2+310+horizontalUploadPhase && y >= ((MaxSize*TileSize)/4*2)-70+verticalUploadPhase && y <= ((MaxSize*TileSize)/4*2)-20+verticalUploadPhase {
-			g.UploadMatrix() // Llamar al método para subir la matriz
-		}
-
-		// Verificar si se presionó el botón "Informed Search"
-		if x >= (MaxSize*TileSize)/2-300+horizontalSelectAlPhase && x <= (MaxSize*TileSize)/2-100+horizontalSelectAlPhase && y >= 250+verticalSelectAlPhase && y <= 300+verticalSelectAlPhase {
-			g.algorithms = informedAlgorithms
-		}
-
-		// Verificar si se presionó el botón "Unnformed Search"
-		if x >= (MaxSize*TileSize)/2-300+horizontalSelectAlPhase && x <= (MaxSize*TileSize)/2-100+horizontalSelectAlPhase && y >= 300+verticalSelectAlPhase && y <= 350+verticalSelectAlPhase {
-			g.algorithms = uninformedAlgorithms
-		}
+	switch {
+	case input.Pressed(ebiten.KeyArrowLeft):
+		g.menu.focusList(g.menu.files)
+	case input.Pressed(ebiten.KeyArrowRight):
+		g.menu.focusList(g.menu.algorithms)
+	case input.Pressed(ebiten.KeyEnter):
+		// Confirmar la selección, StartGame ignora las selecciones incompletas
+		g.StartGame()
 	}
 }
 
 func (g *Game) UpdateGame() {
-	// Move the car along its path
-	g.car.Update()
+	g.camera.SetViewport(g.boardViewport())
+	carCenter := image.Pt(g.car.PosX*TileSize+TileSize/2, g.car.PosY*TileSize+TileSize/2)
+	g.camera.Update(&carCenter)
+
+	if g.exploration != nil && !g.exploration.Finished {
+		// The car waits until the playback of the search finishes
+		g.exploration.Update()
+	} else {
+		// Move the car along its path
+		if g.carControls != nil {
+			g.carControls.Update()
+			g.directions.Update()
+		}
+		g.car.Update()
+	}
 
-	// Check if the car reaches the passenger
-	if g.car.PosX == g.scene.PassengerPosX && g.car.PosY == g.scene.PassengerPosY {
+	// Check if the car reaches the passenger, rewinding can put her back
+	pickedUp := g.car.Passed(g.scene.PassengerPosX, g.scene.PassengerPosY)
+	if pickedUp && g.passenger != nil {
 		// Remove the passenger or handle pickup logic
 		g.passenger = nil // Passenger disappears after being picked up
 		g.car.SetImageWithPassenger()
+	} else if !pickedUp && g.passenger == nil {
+		passenger, err := entities.NewPassenger(g.scene.PassengerPosX, g.scene.PassengerPosY)
+		if err != nil {
+			log.Print(i18n.T("log.passenger", err))
+		}
+		g.passenger = passenger
+		g.car.SetImageWithoutPassenger()
 	}
 
-	// Check if the car reaches the goal
-	if g.car.PosX == g.scene.GoalPosX && g.car.PosY == g.scene.GoalPosY {
-		// Handle reaching the goal (e.g., end the game or display success)
+	// The passenger gets off when the car reaches the goal at the end of the path
+	if g.tripFinished() {
+		g.finishTrip()
+		return
 	}
 
-	// Handle the mouse click for the "Back to Menu" button
-	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
-		x, y := ebiten.CursorPosition()
-		backButtonWidth := 120
-		backButtonHeight := 40
-		screenWidth, _ := ebiten.WindowSize()
+	// Handle the "Back to Menu" and "Game Stats" buttons
+	switch {
+	case input.Pressed(ebiten.KeyEscape) || input.Clicked(gameBackButtonRect):
+		g.state = MenuState
+	case input.Clicked(statsButtonRect):
+		g.state = EndState
+	}
+}
 
-		// Coordinates for the back button
-		backButtonX := screenWidth - backButtonWidth
-		backButtonY := MaxSize*TileSize + 10
+// StartComparison runs the algorithms picked with Ctrl+click over the selected
+// map, or every algorithm of the current group if less than two were picked
+func (g *Game) StartComparison() {
+	if g.selectedFileIndex < 0 || g.selectedFileIndex >= len(g.files) {
+		return
+	}
+	var algorithms []string
+	for _, algorithm := range searchAlgorithms.AlgorithmNames {
+		if g.comparedAlgorithms[algorithm] {
+			algorithms = append(algorithms, algorithm)
+		}
+	}
+	if len(algorithms) < 2 {
+		algorithms = g.algorithms
+	}
+	if err := g.SetScene(g.files[g.selectedFileIndex]); err != nil {
+		log.Print(i18n.T("log.loadMap", g.files[g.selectedFileIndex], err))
+		return
+	}
+	comparison, err := NewComparison(g.scene, Matrix, algorithms)
+	if err != nil {
+		log.Print(i18n.T("log.comparison", err))
+		return
+	}
+	comparison.OnResult = func(name string, result datatypes.SearchResult) {
+		g.recordRun(session.SourceCompare, name, result)
+	}
+	g.comparison = comparison
+	g.state = CompareState
+}
 
-		// Check if the mouse click is within the button's area
-		if x >= backButtonX && x <= backButtonX+backButtonWidth && y >= backButtonY && y <= backButtonY+backButtonHeight {
-			g.state = MenuState // Go back to the menu state
-			print("Going back to the menu...")
+// OpenEditor edits a copy of the selected map, or a blank one if none is selected
+func (g *Game) OpenEditor() {
+	var matrix datatypes.Matrix
+	if g.selectedFileIndex >= 0 && g.selectedFileIndex < len(g.files) {
+		if selected, err := utils.GetMatrix(g.files[g.selectedFileIndex]); err == nil {
+			matrix = selected.Matrix
 		}
+	}
+	editor, err := NewEditor(matrix)
+	if err != nil {
+		log.Print(i18n.T("log.editor", err))
+		return
+	}
+	g.editor = editor
+	g.state = EditorState
+}
 
-		// Handle the mouse click for the "Game Stats" button
-		statsButtonWidth := 120
-		statsButtonHeight := 40
-		statsButtonX := 0
-		statsButtonY := MaxSize*TileSize + 10
-
-		// Check if the mouse click is within the button's area
-		if x >= statsButtonX && x <= statsButtonX+statsButtonWidth && y >= statsButtonY && y <= statsButtonY+statsButtonHeight {
-			// Handle the click for the "Game Stats" button
-			g.state = EndState
-			print("Game Stats")
+func (g *Game) UpdateEditor() {
+	g.editor.Update()
+	if g.editor.Saved != "" {
+		// The saved map shows up in the menu right away, already selected
+		g.refreshFiles()
+		for i, file := range g.files {
+			if file == g.editor.Saved {
+				g.selectedFileIndex = i
+			}
 		}
+		g.editor.Saved = ""
+	}
+	if g.editor.Done {
+		g.state = MenuState
 	}
 }
 
 func (g *Game) UploadMatrix() {
 	fileName, err := zenity.SelectFile(
-		zenity.Title("Select a Matrix File"),
-		zenity.FileFilter{
-			Name:     "Text Files",
-			Patterns: []string{"*.txt"},
+		zenity.Title(i18n.T("upload.title")),
+		zenity.FileFilters{
+			{Name: i18n.T("upload.matrixFiles"), Patterns: []string{"*.txt", "*.png"}},
+			{Name: i18n.T("upload.textFiles"), Patterns: []string{"*.txt"}},
+			{Name: i18n.T("upload.pngMaps"), Patterns: []string{"*.png"}},
 		},
 	)
 	if err != nil {
 		if err == zenity.ErrCanceled {
-			log.Println("No file selected or operation was canceled")
+			log.Println(i18n.T("upload.canceled"))
 			return
 		}
-		log.Printf("Error selecting file: %v", err)
+		log.Print(i18n.T("log.selectFile", err))
 		return
 	}
 
-	targetDir := "../battery"
-	if _, err := os.Stat(targetDir); os.IsNotExist(err) {
-		err := os.MkdirAll(targetDir, os.ModePerm)
-		if err != nil {
-			log.Fatalf("Error creating battery directory: %v", err)
-		}
+	targetDir := utils.MapDir()
+	if err := os.MkdirAll(targetDir, os.ModePerm); err != nil {
+		log.Print(i18n.T("log.mapDir", err))
+		return
 	}
 
 	targetPath := filepath.Join(targetDir, filepath.Base(fileName))
 
-	// Copiar el archivo seleccionado a la carpeta 'battery'
-	err = copyFile(fileName, targetPath)
-	if err != nil {
-		log.Fatalf("Error copying file: %v", err)
+	if strings.EqualFold(filepath.Ext(fileName), ".png") {
+		// Los mapas dibujados se convierten con la paleta por defecto
+		matrix, err := utils.GetMatrixFromImage(fileName, utils.ImageImportOptions{})
+		if err != nil {
+			log.Print(i18n.T("log.importImage", err))
+			return
+		}
+		targetPath, err = utils.SaveMap(strings.TrimSuffix(filepath.Base(fileName), filepath.Ext(fileName))+".txt", matrix.Matrix)
+		if err != nil {
+			log.Print(i18n.T("log.saveMatrix", err))
+			return
+		}
+	} else {
+		// Copiar el archivo seleccionado a la carpeta de mapas
+		err = copyFile(fileName, targetPath)
+		if err != nil {
+			log.Print(i18n.T("log.copyFile", err))
+			return
+		}
 	}
 
-	log.Printf("File copied to %s successfully.", targetPath)
+	log.Print(i18n.T("upload.copied", targetPath))
 
 	// Establecer la nueva escena utilizando el archivo copiado
-	g.SetScene(targetPath)
-	g.files = g.ListMatrixFiles()
+	if err := g.SetScene(targetPath); err != nil {
+		log.Print(i18n.T("log.loadMap", targetPath, err))
+	}
+	g.refreshFiles()
 }
 
 // copyFile copia un archivo de origen a un destino
@@ -501,26 +510,47 @@ func copyFile(src, dst string) error {
 	return nil
 }
 
-func (g *Game) ListMatrixFiles() []string {
-	batteryDir := "../battery"
-	files, err := os.ReadDir(batteryDir)
+// refreshFiles lists the maps again, the old list is kept if that fails
+func (g *Game) refreshFiles() {
+	files, err := utils.ListMaps()
 	if err != nil {
-		log.Fatalf("Error reading battery directory: %v", err)
+		log.Print(i18n.T("log.listMaps", err))
+		return
 	}
+	g.files = files
+}
 
-	var fileNames []string
-	for _, file := range files {
-		if !file.IsDir() {
-			fileNames = append(fileNames, file.Name())
-		}
+// SetCarPath starts the search in the background, the car gets its path
+// when the search ends
+func (g *Game) SetCarPath(algorithmKey string) {
+	if g.search != nil {
+		g.search.Cancel()
 	}
+	g.search = StartBackgroundSearch(algorithmKey, Matrix)
+	g.currentAlgorithm = algorithmKey
+	g.state = SearchingState
+}
 
-	return fileNames
+func (g *Game) UpdateSearching() {
+	if g.search.CancelRequested() {
+		g.search.Cancel()
+		g.search = nil
+		g.state = MenuState
+		return
+	}
+	if outcome, done := g.search.Poll(); done {
+		g.search = nil
+		if outcome.err == nil {
+			g.recordRun(session.SourceGame, g.currentAlgorithm, outcome.result)
+		}
+		g.applySearchOutcome(outcome)
+		g.state = PlayingState
+	}
 }
 
-func (g *Game) SetCarPath(algorithmKey string) {
+// applySearchOutcome gives the car the path found by a finished search
+func (g *Game) applySearchOutcome(outcome searchOutcome) {
 	var newPath [][]int // Declare newPath outside the conditional block
-	startTime := time.Now()
 
 	processResultFunc := func(results datatypes.SearchResult) {
 		var mappedCoordinates [][]int
@@ -531,74 +561,70 @@ func (g *Game) SetCarPath(algorithmKey string) {
 		g.nodesExpanded = results.ExpandenNodes
 		g.treeDepth = results.TreeDepth
 		g.solutionCost = float64(results.Cost)
+		g.searchStatus = results.Status
 	}
 
-	switch algorithmKey {
-	case "Breadth First Algorithm":
-		result := searchAlgorithms.StartSearch(1, Matrix)
-		processResultFunc(result)
-	case "A*":
-		envMatrix, _ := searchAlgorithms.ValidateMatrix(Matrix.Matrix)
-		env, err := searchAlgorithms.NewEnvironment(envMatrix)
-		if err != nil {
-			log.Fatalf("Error creating environment: %v", err)
-		}
-		aStarSearch := new(searchAlgorithms.AStarSearch)
-		agent := searchAlgorithms.NewAgent(env.InitPosition, aStarSearch)
-
-		result := agent.SearchAlgorithm.LookForGoal(env)
-		newPath = searchAlgorithms.FromPosToPath(result.Path)
-		g.nodesExpanded = result.ExpandedNodes
-		g.treeDepth = result.TreeDepth
-		g.solutionCost = float64(result.Cost)
-	case "Avaro":
-		envMatrix, _ := searchAlgorithms.ValidateMatrix(Matrix.Matrix)
-		env, err := searchAlgorithms.NewEnvironment(envMatrix)
-		if err != nil {
-			log.Fatalf("Error creating environment: %v", err)
-		}
-		miserSearch := new(searchAlgorithms.MiserSearch)
-		agent := searchAlgorithms.NewAgent(env.InitPosition, miserSearch)
-		result := agent.SearchAlgorithm.LookForGoal(env)
-		newPath = searchAlgorithms.FromPosToPath(result.Path)
-		g.nodesExpanded = result.ExpandedNodes
-		g.treeDepth = result.TreeDepth
-		g.solutionCost = float64(result.Cost)
-
-	case "DepthSearch":
-		result := searchAlgorithms.StartSearch(3, Matrix)
-		processResultFunc(result)
-
-	case "Uniform Cost Search":
-		result := searchAlgorithms.StartSearch(2, Matrix) // Call the dummy algorithm
-		processResultFunc(result)
-	default:
-		newPath = [][]int{} // Initialize with an empty slice for other cases
-	}
-	g.computationTime = time.Since(startTime).Seconds()
+	if outcome.err != nil {
+		log.Print(i18n.T("log.search", outcome.err))
+		newPath = [][]int{}
+	} else {
+		processResultFunc(outcome.result)
+	}
+	g.exploration = NewSearchPlayback(outcome.trace)
+	g.computationTime = outcome.elapsed.Seconds()
 
 	if g.car.PosX != g.car.InitialPosX && g.car.PosY != g.car.InitialPosY {
 		g.car.Reset() // Reset the car position if it's not at the initial position
 	}
 	g.car.SetPath(newPath)
+	if g.carControls != nil {
+		// The new car goes as fast as the last one
+		g.carSpeed = g.carControls.Speed()
+	}
+	g.carControls = NewCarControls(g.car)
+	g.carControls.SetSpeed(g.carSpeed)
+	// The panel stays open for the next search
+	open := g.directions != nil && g.directions.Open
+	g.directions = NewDirectionsPanel(Matrix, outcome.result.PathFound)
+	g.directions.Open = open
 
 	// Check if the passenger is nil (i.e., removed)
 	if g.passenger == nil {
 		// Create a new passenger and reset it to the initial position
-		g.passenger = entities.NewPassenger(g.scene.PassengerPosX, g.scene.PassengerPosY)
+		passenger, err := entities.NewPassenger(g.scene.PassengerPosX, g.scene.PassengerPosY)
+		if err != nil {
+			log.Print(i18n.T("log.passenger", err))
+		}
+		g.passenger = passenger
 	}
 }
 
-func (g *Game) SetScene(fileName string) {
+// SetScene loads a map, the current one is kept if it can't be loaded
+func (g *Game) SetScene(fileName string) error {
+	matrix, err := utils.GetMatrix(fileName)
+	if err != nil {
+		return err
+	}
 
-	Matrix, _ = utils.GetMatrix(fileName)
 	// Create the scene
-	Matrix, _ = utils.GetMatrix(fileName)
-	g.scene = NewScene(Matrix.Matrix)
+	scene, err := NewScene(matrix.Matrix)
+	if err != nil {
+		return err
+	}
 
-	scene := NewScene(Matrix.Matrix)
+	car, err := entities.NewCar(scene.CarPosX, scene.CarPosY)
+	if err != nil {
+		return err
+	}
 
-	g.car = entities.NewCar(scene.CarPosX, scene.CarPosY)
+	passenger, err := entities.NewPassenger(scene.PassengerPosX, scene.PassengerPosY)
+	if err != nil {
+		return err
+	}
 
-	g.passenger = entities.NewPassenger(scene.PassengerPosX, scene.PassengerPosY)
+	Matrix = matrix
+	g.currentMap = fileName
+	g.scene, g.car, g.passenger = scene, car, passenger
+	g.world, g.camera = newBoardView(scene)
+	return nil
 }
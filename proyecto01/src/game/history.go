@@ -0,0 +1,151 @@
+package game
+
+import (
+	"fmt"
+	"image"
+	"log"
+
+	"github.com/Krud3/InteligenciaArtificial/src/datatypes"
+	"github.com/Krud3/InteligenciaArtificial/src/i18n"
+	"github.com/Krud3/InteligenciaArtificial/src/input"
+	"github.com/Krud3/InteligenciaArtificial/src/session"
+	"github.com/Krud3/InteligenciaArtificial/src/visuals"
+	"github.com/Krud3/InteligenciaArtificial/src/visuals/components"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/ncruces/zenity"
+)
+
+// Runs shown on each page of the history
+const historyPageSize = 18
+
+// HistoryScreen lists the searches of the history, newest first, a page at a time
+type HistoryScreen struct {
+	entries    []session.Entry
+	page       int
+	table      *components.Table
+	message    string // Result of the last export
+	playground components.Playground
+}
+
+func newHistoryScreen(g *Game) *HistoryScreen {
+	history := &HistoryScreen{}
+	history.table = components.NewTable(image.Pt(20, 70),
+		components.Column{Title: i18n.T("history.when"), Width: 110},
+		components.Column{Title: i18n.T("history.map"), Width: 120},
+		components.Column{Title: i18n.T("common.algorithm"), Width: 110},
+		components.Column{Title: i18n.T("common.cost"), Width: 50},
+		components.Column{Title: i18n.T("history.nodes"), Width: 70},
+		components.Column{Title: i18n.T("common.timeMs"), Width: 70},
+		components.Column{Title: i18n.T("common.status"), Width: 70},
+	)
+	history.table.Sortable = true
+
+	buttons := components.Columns(image.Rect(20, 600, MaxSize*TileSize-20, 650), 4, 10)
+	newer := &components.Button{Rect: buttons[0], Label: i18n.T("history.newer"), Color: visuals.Navy, OnClick: func() { history.setPage(history.page - 1) },
+		Disabled: func() bool { return history.page == 0 }}
+	older := &components.Button{Rect: buttons[1], Label: i18n.T("history.older"), Color: visuals.Navy, OnClick: func() { history.setPage(history.page + 1) },
+		Disabled: func() bool { return history.page >= history.pages()-1 }}
+	export := &components.Button{Rect: buttons[2], Label: i18n.T("history.export"), Color: visuals.Blue, OnClick: history.export,
+		Disabled: func() bool { return len(history.entries) == 0 }}
+	back := &components.Button{Rect: buttons[3], Label: i18n.T("common.returnToMenu"), Color: visuals.Green, OnClick: func() { g.state = MenuState }}
+	history.playground.Add(&components.Label{Pos: image.Pt(20, 30), Text: i18n.T("history.title")}, history.table, newer, older, export, back)
+	history.playground.Focus(back)
+	return history
+}
+
+// Reload reads the history again and goes back to the newest runs
+func (h *HistoryScreen) Reload() {
+	entries, err := session.History()
+	if err != nil {
+		log.Print(i18n.T("log.readHistory", err))
+	}
+	// Newest first
+	h.entries = make([]session.Entry, len(entries))
+	for i, entry := range entries {
+		h.entries[len(entries)-1-i] = entry
+	}
+	h.message = ""
+	h.setPage(0)
+}
+
+func (h *HistoryScreen) pages() int {
+	return max(1, (len(h.entries)+historyPageSize-1)/historyPageSize)
+}
+
+func (h *HistoryScreen) setPage(page int) {
+	h.page = max(0, min(page, h.pages()-1))
+	first := h.page * historyPageSize
+	last := min(first+historyPageSize, len(h.entries))
+	rows := make([]components.TableRow, 0, last-first)
+	for _, entry := range h.entries[first:last] {
+		rows = append(rows, components.TableRow{Cells: []string{
+			entry.Time.Local().Format("01-02 15:04"),
+			entry.Map,
+			i18n.Algorithm(entry.Algorithm),
+			fmt.Sprintf("%.0f", entry.Cost),
+			fmt.Sprint(entry.Nodes),
+			fmt.Sprintf("%.3f", float64(entry.Duration.Microseconds())/1000),
+			i18n.Status(entry.Status),
+		}})
+	}
+	h.table.SetRows(rows)
+}
+
+// export asks where to save the whole history as CSV
+func (h *HistoryScreen) export() {
+	path, err := zenity.SelectFileSave(
+		zenity.Title(i18n.T("history.exportTitle")),
+		zenity.Filename("history.csv"),
+		zenity.ConfirmOverwrite(),
+		zenity.FileFilters{{Name: i18n.T("history.csvFiles"), Patterns: []string{"*.csv"}}},
+	)
+	if err != nil {
+		if err != zenity.ErrCanceled {
+			log.Print(i18n.T("log.selectFile", err))
+		}
+		return
+	}
+	if err := session.ExportCSV(path); err != nil {
+		h.message = i18n.T("history.exportFailed", err)
+		return
+	}
+	h.message = i18n.T("history.exported", path)
+}
+
+func (h *HistoryScreen) Update() {
+	h.playground.Update()
+}
+
+func (h *HistoryScreen) Draw(screen *ebiten.Image) {
+	h.playground.Draw(screen)
+	if len(h.entries) == 0 {
+		ebitenutil.DebugPrintAt(screen, i18n.T("history.empty"), 20, 110)
+	}
+	ebitenutil.DebugPrintAt(screen, i18n.T("history.page", h.page+1, h.pages(), len(h.entries)), 20, 570)
+	if h.message != "" {
+		ebitenutil.DebugPrintAt(screen, h.message, 20, 660)
+	}
+}
+
+// OpenHistory shows the history with the runs recorded until now
+func (g *Game) OpenHistory() {
+	g.history.Reload()
+	g.state = HistoryState
+}
+
+func (g *Game) UpdateHistory() {
+	if input.Pressed(ebiten.KeyEscape) {
+		g.state = MenuState
+		return
+	}
+	g.history.Update()
+}
+
+// recordRun adds a search of the current map to the history
+func (g *Game) recordRun(source, algorithm string, result datatypes.SearchResult) {
+	entry := session.NewEntry(source, g.currentMap, Matrix.Matrix, algorithm, result)
+	if err := session.Record(entry); err != nil {
+		log.Print(i18n.T("log.recordRun", err))
+	}
+}
@@ -0,0 +1,196 @@
+package game
+
+import (
+	"fmt"
+	"image"
+	"log"
+
+	"github.com/Krud3/InteligenciaArtificial/src/i18n"
+	"github.com/Krud3/InteligenciaArtificial/src/visuals"
+	"github.com/Krud3/InteligenciaArtificial/src/visuals/components"
+)
+
+// Width of the columns of the menu, the maps on the left and the algorithms
+// on the right
+const menuColumnWidth = 220
+
+// Menu holds the widgets of the main menu, the lists are filled from the
+// Game on every frame so they always show its state
+type Menu struct {
+	playground components.Playground
+	files      *components.List
+	algorithms *components.List
+	group      *components.Dropdown
+	theme      *components.Button
+	language   *components.Button
+}
+
+func newMenu(g *Game) *Menu {
+	menu := &Menu{}
+	title := &components.Image{Pos: image.Pt((MaxSize*TileSize-g.titleImage.Bounds().Dx())/2, 50), Image: g.titleImage}
+	noFile := func() bool { return g.selectedFileIndex < 0 }
+
+	maps := components.Stack{X: 35, Y: 250, Width: menuColumnWidth, Gap: 10}
+	upload := &components.Button{Rect: maps.Next(50), Label: i18n.T("menu.upload"), Color: visuals.Blue, OnClick: g.UploadMatrix}
+	menu.files = &components.List{
+		Rect:       maps.Next(210),
+		OnSelect:   func(index int) { g.selectedFileIndex = index },
+		OnActivate: func(int) { g.StartGame() },
+	}
+	drive := &components.Button{Rect: maps.Next(50), Label: i18n.T("menu.drive"), Color: visuals.Green, OnClick: g.StartDriving, Disabled: noFile}
+	editor := &components.Button{Rect: maps.Next(50), Label: i18n.T("menu.editor"), Color: visuals.Blue, OnClick: g.OpenEditor}
+
+	algorithms := components.Stack{X: MaxSize*TileSize - 35 - menuColumnWidth, Y: 250, Width: menuColumnWidth, Gap: 10}
+	menu.group = &components.Dropdown{
+		Rect:     algorithms.Next(50),
+		Options:  []string{i18n.T("menu.informed"), i18n.T("menu.uninformed")},
+		Color:    visuals.Orange,
+		OnChange: func(index int) { g.SetAlgorithmType(AlgorithmType(index)) },
+	}
+	menu.algorithms = &components.List{
+		Rect:       algorithms.Next(120),
+		OnSelect:   func(index int) { g.selectedAlgorithmIndex = index },
+		OnActivate: func(int) { g.StartGame() },
+		// Ctrl+click or Space adds or removes an algorithm from the comparison
+		OnToggle: func(index int) {
+			algorithm := g.algorithms[index]
+			g.comparedAlgorithms[algorithm] = !g.comparedAlgorithms[algorithm]
+		},
+		Marked: func(index int) bool { return g.comparedAlgorithms[g.algorithms[index]] },
+	}
+	compare := &components.Button{Rect: algorithms.Next(50), Label: i18n.T("menu.compare"), Color: visuals.Orange, OnClick: g.StartComparison, Disabled: noFile}
+	start := &components.Button{
+		Rect:     algorithms.Next(100),
+		Label:    i18n.T("menu.start"),
+		Color:    visuals.Green,
+		OnClick:  g.StartGame,
+		Disabled: func() bool { return g.selectedFileIndex < 0 || g.selectedAlgorithmIndex < 0 },
+	}
+	extras := components.Columns(algorithms.Next(40), 2, 10)
+	history := &components.Button{Rect: extras[0], Label: i18n.T("menu.history"), Color: visuals.Navy, OnClick: g.OpenHistory}
+	menu.theme = &components.Button{Rect: extras[1], Color: visuals.Navy, OnClick: func() {
+		visuals.NextTheme()
+		g.SaveSettings()
+	}}
+
+	// The dropdown goes last so its options are drawn over the algorithms
+	// Over the title, it rebuilds the screens in the other language
+	menu.language = &components.Button{Rect: image.Rect(MaxSize*TileSize-35-100, 10, MaxSize*TileSize-35, 40), Label: i18n.T("language.name"), Color: visuals.Navy, OnClick: g.NextLanguage}
+
+	menu.playground.Add(title, menu.language, upload, menu.files, drive, editor, menu.algorithms, compare, start, history, menu.theme, menu.group)
+	// The arrows move through the maps right away, like they always did
+	menu.playground.Focus(menu.files)
+	return menu
+}
+
+// sync copies the state of the game into the widgets, dropping selections
+// that aren't valid anymore so they can't start a game
+func (m *Menu) sync(g *Game) {
+	if g.selectedFileIndex >= len(g.files) {
+		g.selectedFileIndex = -1
+	}
+	if g.selectedAlgorithmIndex >= len(g.algorithms) {
+		g.selectedAlgorithmIndex = -1
+	}
+	m.files.Items = g.files
+	if m.files.Selected != g.selectedFileIndex && g.selectedFileIndex >= 0 {
+		// Selected with the keyboard, it has to be visible
+		m.files.ScrollTo(g.selectedFileIndex)
+	}
+	m.files.Selected = g.selectedFileIndex
+	m.algorithms.Items = i18n.Algorithms(g.algorithms)
+	m.algorithms.Selected = g.selectedAlgorithmIndex
+	m.group.Selected = int(g.algorithmType)
+	m.theme.Label = i18n.T("menu.theme", visuals.ThemeName())
+}
+
+// focusList moves the focus to a list, selecting its first item if none is
+func (m *Menu) focusList(list *components.List) {
+	if !list.CanFocus() {
+		return
+	}
+	m.playground.Focus(list)
+	if list.Selected < 0 && list.OnSelect != nil {
+		list.Selected = 0
+		list.OnSelect(0)
+	}
+}
+
+// SetAlgorithmType switches the list of algorithms between the informed and
+// the uninformed ones
+func (g *Game) SetAlgorithmType(algorithmType AlgorithmType) {
+	g.algorithmType = algorithmType
+	if algorithmType == InformedAlgorithm {
+		g.algorithms = informedAlgorithms
+	} else {
+		g.algorithms = uninformedAlgorithms
+	}
+	g.selectedAlgorithmIndex = min(g.selectedAlgorithmIndex, len(g.algorithms)-1)
+}
+
+// StartGame searches the selected map with the selected algorithm, it does
+// nothing until both are selected
+func (g *Game) StartGame() {
+	if g.selectedFileIndex < 0 || g.selectedFileIndex >= len(g.files) || g.selectedAlgorithmIndex < 0 || g.selectedAlgorithmIndex >= len(g.algorithms) {
+		return
+	}
+	selectedFile := g.files[g.selectedFileIndex]
+	// Cargar la nueva escena con el archivo seleccionado
+	if err := g.SetScene(selectedFile); err != nil {
+		log.Print(i18n.T("log.loadMap", selectedFile, err))
+		return
+	}
+	// The next launch starts with this map and algorithm selected
+	g.SaveSettings()
+	g.SetCarPath(g.algorithms[g.selectedAlgorithmIndex])
+}
+
+// StartDriving lets the user drive the selected map
+func (g *Game) StartDriving() {
+	if g.selectedFileIndex < 0 {
+		return
+	}
+	if err := g.SetScene(g.files[g.selectedFileIndex]); err != nil {
+		log.Print(i18n.T("log.loadMap", g.files[g.selectedFileIndex], err))
+		return
+	}
+	driving, err := NewDriving(g.scene, Matrix)
+	if err != nil {
+		log.Print(i18n.T("log.driving", err))
+		return
+	}
+	g.driving = driving
+	g.state = DriveState
+}
+
+// EndScreen is the report of the last search
+type EndScreen struct {
+	playground components.Playground
+	stats      *components.Table
+}
+
+func newEndScreen(g *Game) *EndScreen {
+	end := &EndScreen{
+		stats: components.NewTable(image.Pt(50, 90), components.Column{Title: i18n.T("end.metric"), Width: 200}, components.Column{Title: i18n.T("end.value"), Width: 200}),
+	}
+	back := &components.Button{Rect: image.Rect(50, 550, 200, 600), Label: i18n.T("common.returnToMenu"), Color: visuals.Green, OnClick: func() { g.state = MenuState }}
+	end.playground.Add(&components.Label{Pos: image.Pt(50, 50), Text: i18n.T("end.title")}, end.stats, back)
+	// Enter returns right away
+	end.playground.Focus(back)
+	return end
+}
+
+// sync fills the table with the stats of the last search
+func (e *EndScreen) sync(g *Game) {
+	rows := []components.TableRow{
+		{Cells: []string{i18n.T("end.nodes"), fmt.Sprint(g.nodesExpanded)}},
+		{Cells: []string{i18n.T("end.depth"), fmt.Sprint(g.treeDepth)}},
+		{Cells: []string{i18n.T("end.time"), i18n.T("end.seconds", g.computationTime)}},
+	}
+	// Only display the solution cost if applicable
+	if g.solutionCost > 0 {
+		rows = append(rows, components.TableRow{Cells: []string{i18n.T("end.cost"), fmt.Sprintf("%.2f", g.solutionCost)}})
+	}
+	rows = append(rows, components.TableRow{Cells: []string{i18n.T("common.status"), i18n.Status(g.searchStatus.String())}})
+	e.stats.SetRows(rows)
+}
@@ -0,0 +1,146 @@
+package game
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+
+	"github.com/Krud3/InteligenciaArtificial/src/game/entities"
+	"github.com/Krud3/InteligenciaArtificial/src/i18n"
+	"github.com/Krud3/InteligenciaArtificial/src/input"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// Frames the car waits between moves at each speed, slowest first
+var carDelays = []int{90, 60, 30, 15, 8, 4}
+
+const defaultCarSpeed = 2
+
+// Controls of the car, in the bar under the board between the other buttons
+var (
+	rewindButtonRect   = image.Rect(130, MaxSize*TileSize+8, 160, MaxSize*TileSize+30)
+	stepBackButtonRect = image.Rect(164, MaxSize*TileSize+8, 194, MaxSize*TileSize+30)
+	playButtonRect     = image.Rect(198, MaxSize*TileSize+8, 248, MaxSize*TileSize+30)
+	stepButtonRect     = image.Rect(252, MaxSize*TileSize+8, 282, MaxSize*TileSize+30)
+	speedSliderRect    = image.Rect(300, MaxSize*TileSize+12, 420, MaxSize*TileSize+26)
+	scrubberRect       = image.Rect(130, MaxSize*TileSize+36, 510, MaxSize*TileSize+48)
+)
+
+// CarControls lets the user pause the car, move it one step at a time, change
+// its speed and jump to any point of its path
+type CarControls struct {
+	car        *entities.Car
+	speedIndex int
+	dragging   image.Rectangle // Slider being dragged, empty if none
+}
+
+func NewCarControls(car *entities.Car) *CarControls {
+	controls := &CarControls{car: car}
+	controls.SetSpeed(defaultCarSpeed)
+	return controls
+}
+
+func (c *CarControls) Update() {
+	// Shift+arrows move the camera
+	arrows := !input.Shift()
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeySpace):
+		c.car.Paused = !c.car.Paused
+	case arrows && inpututil.IsKeyJustPressed(ebiten.KeyArrowRight):
+		c.car.Paused = true
+		c.car.StepForward()
+	case arrows && inpututil.IsKeyJustPressed(ebiten.KeyArrowLeft):
+		c.car.Paused = true
+		c.car.StepBack()
+	case inpututil.IsKeyJustPressed(ebiten.KeyHome):
+		c.car.Seek(0)
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnd):
+		c.car.Seek(len(c.car.Path))
+	case arrows && inpututil.IsKeyJustPressed(ebiten.KeyArrowUp):
+		c.SetSpeed(c.speedIndex + 1)
+	case arrows && inpututil.IsKeyJustPressed(ebiten.KeyArrowDown):
+		c.SetSpeed(c.speedIndex - 1)
+	}
+
+	cursor := image.Pt(ebiten.CursorPosition())
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		switch {
+		case cursor.In(rewindButtonRect):
+			c.car.Seek(0)
+		case cursor.In(stepBackButtonRect):
+			c.car.Paused = true
+			c.car.StepBack()
+		case cursor.In(playButtonRect):
+			c.car.Paused = !c.car.Paused
+		case cursor.In(stepButtonRect):
+			c.car.Paused = true
+			c.car.StepForward()
+		case cursor.In(speedSliderRect):
+			c.dragging = speedSliderRect
+		case cursor.In(scrubberRect):
+			c.dragging = scrubberRect
+		}
+	}
+	if !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		c.dragging = image.Rectangle{}
+	}
+	switch c.dragging {
+	case speedSliderRect:
+		c.SetSpeed(sliderValue(speedSliderRect, cursor.X, len(carDelays)-1))
+	case scrubberRect:
+		if index := sliderValue(scrubberRect, cursor.X, len(c.car.Path)); index != c.car.Index {
+			c.car.Seek(index)
+		}
+	}
+}
+
+// Speed is the index of the current speed in carDelays
+func (c *CarControls) Speed() int {
+	return c.speedIndex
+}
+
+// SetSpeed picks one of carDelays, out of range values are clamped
+func (c *CarControls) SetSpeed(index int) {
+	c.speedIndex = max(0, min(index, len(carDelays)-1))
+	c.car.Delay = carDelays[c.speedIndex]
+}
+
+func (c *CarControls) Draw(screen *ebiten.Image) {
+	playLabel := i18n.T("game.pause")
+	if c.car.Paused {
+		playLabel = i18n.T("game.play")
+	}
+	for _, button := range []struct {
+		rect  image.Rectangle
+		label string
+	}{{rewindButtonRect, "|<"}, {stepBackButtonRect, " <"}, {playButtonRect, playLabel}, {stepButtonRect, " >"}} {
+		ebitenutil.DrawRect(screen, float64(button.rect.Min.X), float64(button.rect.Min.Y), float64(button.rect.Dx()), float64(button.rect.Dy()), color.RGBA{60, 60, 160, 255})
+		ebitenutil.DebugPrintAt(screen, button.label, button.rect.Min.X+6, button.rect.Min.Y+3)
+	}
+
+	drawSlider(screen, speedSliderRect, c.speedIndex, len(carDelays)-1)
+	speed := float64(carDelays[defaultCarSpeed]) / float64(c.car.Delay)
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("x%.1f %d/%d", speed, c.car.Index, len(c.car.Path)), speedSliderRect.Max.X+8, speedSliderRect.Min.Y-2)
+
+	drawSlider(screen, scrubberRect, c.car.Index, len(c.car.Path))
+}
+
+// sliderValue maps a horizontal position over a slider to 0..steps
+func sliderValue(rect image.Rectangle, x int, steps int) int {
+	if rect.Dx() == 0 || steps == 0 {
+		return 0
+	}
+	value := ((x-rect.Min.X)*steps + rect.Dx()/2) / rect.Dx()
+	return max(0, min(value, steps))
+}
+
+func drawSlider(screen *ebiten.Image, rect image.Rectangle, value, steps int) {
+	ebitenutil.DrawRect(screen, float64(rect.Min.X), float64(rect.Min.Y+rect.Dy()/2-2), float64(rect.Dx()), 4, color.RGBA{120, 120, 120, 255})
+	x := rect.Min.X
+	if steps > 0 {
+		x += rect.Dx() * value / steps
+	}
+	ebitenutil.DrawRect(screen, float64(x-3), float64(rect.Min.Y), 6, float64(rect.Dy()), color.RGBA{255, 255, 255, 255})
+}
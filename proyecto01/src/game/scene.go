@@ -1,15 +1,20 @@
 package game
 
 import (
-	"log"
-
+	"github.com/Krud3/InteligenciaArtificial/src/game/entities"
+	"github.com/Krud3/InteligenciaArtificial/src/i18n"
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 )
 
 const (
 	TileSize = 64
-	MaxSize  = 10
+	// Cells that fit in the window without zooming out, the editor doesn't
+	// make maps bigger than this
+	MaxSize = 10
+	// Cells per side of the biggest map the game loads. The whole board is
+	// drawn into one image, and some graphics drivers don't take images over
+	// 8192 pixels, 128 cells, counting the padding of the atlas
+	MaxBoardSize = 120
 )
 
 type Tile int
@@ -24,9 +29,44 @@ const (
 	Goal
 )
 
+// Openings of a road sprite towards its neighbours
+const (
+	openUp = 1 << iota
+	openRight
+	openDown
+	openLeft
+)
+
+// Road sprites by the sides they open to. Dead ends don't have a sprite so
+// they use the straight road through them
+var roadSprites = map[int]string{
+	0:                                        "calle-uldr.png",
+	openUp:                                   "calle-ud.png",
+	openDown:                                 "calle-ud.png",
+	openLeft:                                 "calle-lr.png",
+	openRight:                                "calle-lr.png",
+	openUp | openDown:                        "calle-ud.png",
+	openLeft | openRight:                     "calle-lr.png",
+	openDown | openRight:                     "calle-dr.png",
+	openLeft | openDown:                      "calle-ld.png",
+	openUp | openRight:                       "calle-ur.png",
+	openUp | openLeft:                        "calle-ul.png",
+	openUp | openDown | openRight:            "calle-dru.png",
+	openUp | openLeft | openRight:            "calle-lur.png",
+	openUp | openLeft | openDown:             "calle-uld.png",
+	openLeft | openDown | openRight:          "calle-ldr.png",
+	openUp | openLeft | openDown | openRight: "calle-uldr.png",
+}
+
+// How opaque the traffic sprites are drawn over the road under them
+const trafficOverlayAlpha = 0.6
+
 type Scene struct {
-	Grid          [MaxSize][MaxSize]Tile
-	Images        map[Tile]*ebiten.Image
+	Grid   [][]Tile
+	Images map[Tile]*ebiten.Image
+	// Road sprite of every road and traffic cell, picked from its neighbours
+	Roads         [][]*ebiten.Image
+	Rows, Columns int
 	CarPosX       int
 	CarPosY       int
 	PassengerPosX int
@@ -35,32 +75,48 @@ type Scene struct {
 	GoalPosY      int
 }
 
-func NewScene(matrix [][]int) *Scene {
+func NewScene(matrix [][]int) (*Scene, error) {
+	if len(matrix) > MaxBoardSize || (len(matrix) > 0 && len(matrix[0]) > MaxBoardSize) {
+		return nil, i18n.Errorf("error.boardTooBig", len(matrix), len(matrix[0]), MaxBoardSize)
+	}
+
 	scene := &Scene{
 		Images: make(map[Tile]*ebiten.Image),
 	}
 
 	// Load images
 	imageFiles := map[Tile]string{
-		Road:          "./game/assets/images/calle-uldr.png",
-		Wall:          "./game/assets/images/muro-1.png",
-		Car:           "./game/assets/images/moto-1-narvaez.png",
-		MediumTraffic: "./game/assets/images/trafico-medio.png",
-		HighTraffic:   "./game/assets/images/trafico-pesado.png",
-		Passenger:     "./game/assets/images/girl.png",
-		Goal:          "./game/assets/images/destino.png",
+		Road:          "calle-uldr.png",
+		Wall:          "muro-1.png",
+		Car:           "moto-1-narvaez.png",
+		MediumTraffic: "trafico-medio.png",
+		HighTraffic:   "trafico-pesado.png",
+		Passenger:     "girl.png",
+		Goal:          "destino.png",
 	}
 
 	for tile, file := range imageFiles {
-		img, _, err := ebitenutil.NewImageFromFile(file)
+		img, err := entities.LoadImage(file)
 		if err != nil {
-			log.Fatal(err)
+			return nil, err
 		}
 		scene.Images[tile] = img
 	}
+	roadImages := make(map[string]*ebiten.Image)
+	for _, file := range roadSprites {
+		img, err := entities.LoadImage(file)
+		if err != nil {
+			return nil, err
+		}
+		roadImages[file] = img
+	}
 
 	// Populate grid
+	scene.Grid = make([][]Tile, len(matrix))
+	scene.Roads = make([][]*ebiten.Image, len(matrix))
 	for y, row := range matrix {
+		scene.Grid[y] = make([]Tile, len(row))
+		scene.Roads[y] = make([]*ebiten.Image, len(row))
 		for x, val := range row {
 			scene.Grid[y][x] = Tile(val)
 			if Tile(val) == Car {
@@ -80,15 +136,63 @@ func NewScene(matrix [][]int) *Scene {
 		}
 	}
 
-	return scene
+	scene.Rows = len(matrix)
+	if scene.Rows > 0 {
+		scene.Columns = len(matrix[0])
+	}
+	for y := 0; y < scene.Rows; y++ {
+		for x := 0; x < scene.Columns; x++ {
+			scene.Roads[y][x] = roadImages[roadSprites[scene.openings(x, y)]]
+		}
+	}
+
+	return scene, nil
+}
+
+// openings returns the sides of a cell that lead to a cell that isn't a wall
+func (s *Scene) openings(x, y int) int {
+	open := func(x, y int) bool {
+		return x >= 0 && y >= 0 && x < s.Columns && y < s.Rows && s.Grid[y][x] != Wall
+	}
+	sides := 0
+	if open(x, y-1) {
+		sides |= openUp
+	}
+	if open(x+1, y) {
+		sides |= openRight
+	}
+	if open(x, y+1) {
+		sides |= openDown
+	}
+	if open(x-1, y) {
+		sides |= openLeft
+	}
+	return sides
+}
+
+// Width and height of the board in pixels
+func (s *Scene) Size() (int, int) {
+	return s.Columns * TileSize, s.Rows * TileSize
 }
 
 func (s *Scene) Draw(screen *ebiten.Image) {
-	for y := 0; y < MaxSize; y++ {
-		for x := 0; x < MaxSize; x++ {
+	for y := 0; y < s.Rows; y++ {
+		for x := 0; x < s.Columns; x++ {
 			op := &ebiten.DrawImageOptions{}
 			op.GeoM.Translate(float64(x*TileSize), float64(y*TileSize))
-			screen.DrawImage(s.Images[s.Grid[y][x]], op)
+			tile := s.Grid[y][x]
+			road := s.Roads[y][x]
+			switch {
+			case road != nil && tile == Road:
+				screen.DrawImage(road, op)
+			case road != nil && (tile == MediumTraffic || tile == HighTraffic):
+				// The traffic shows over the street so the autotiling is still visible
+				screen.DrawImage(road, op)
+				op.ColorScale.ScaleAlpha(trafficOverlayAlpha)
+				screen.DrawImage(s.Images[tile], op)
+			default:
+				screen.DrawImage(s.Images[tile], op)
+			}
 		}
 	}
 }
@@ -0,0 +1,106 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"sync/atomic"
+	"time"
+
+	"github.com/Krud3/InteligenciaArtificial/src/datatypes"
+	"github.com/Krud3/InteligenciaArtificial/src/i18n"
+	"github.com/Krud3/InteligenciaArtificial/src/searchAlgorithms"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// Limits of the searches run from the game, so a runaway search ends on its own
+var gameSearchLimits = searchAlgorithms.Limits{Timeout: time.Minute, MaxExpansions: 5_000_000, MaxMemory: 1 << 30}
+
+var cancelSearchButtonRect = image.Rect(MaxSize*TileSize-130, MaxSize*TileSize+10, MaxSize*TileSize-10, MaxSize*TileSize+50)
+
+// searchOutcome is what a BackgroundSearch delivers when it ends
+type searchOutcome struct {
+	result  datatypes.SearchResult
+	trace   *searchAlgorithms.SearchTrace
+	err     error
+	elapsed time.Duration
+}
+
+// progressObserver counts the expansions, it's written by the search
+// goroutine and read by the game loop
+type progressObserver struct {
+	searchAlgorithms.BaseObserver
+	expanded *atomic.Int64
+}
+
+func (p progressObserver) OnExpand(searchAlgorithms.SearchNode) {
+	p.expanded.Add(1)
+}
+
+// BackgroundSearch runs an algorithm in its own goroutine so the window keeps
+// responding, the game polls it every frame
+type BackgroundSearch struct {
+	algorithm string
+	cancel    context.CancelFunc
+	done      chan searchOutcome
+	expanded  atomic.Int64
+	started   time.Time
+}
+
+func StartBackgroundSearch(algorithm string, matrix datatypes.ScannedMatrix) *BackgroundSearch {
+	ctx, cancel := context.WithCancel(context.Background())
+	search := &BackgroundSearch{
+		algorithm: algorithm,
+		cancel:    cancel,
+		// Buffered so the goroutine never blocks if nobody reads the result
+		done:    make(chan searchOutcome, 1),
+		started: time.Now(),
+	}
+	go func() {
+		trace := &searchAlgorithms.SearchTrace{}
+		observer := searchAlgorithms.Observers(trace, progressObserver{expanded: &search.expanded})
+		result, err := searchAlgorithms.RunLimited(ctx, algorithm, matrix, observer, gameSearchLimits)
+		search.done <- searchOutcome{result: result, trace: trace, err: err, elapsed: time.Since(search.started)}
+	}()
+	return search
+}
+
+// Poll returns the outcome once the search ended, without blocking
+func (s *BackgroundSearch) Poll() (searchOutcome, bool) {
+	select {
+	case outcome := <-s.done:
+		return outcome, true
+	default:
+		return searchOutcome{}, false
+	}
+}
+
+func (s *BackgroundSearch) Cancel() {
+	s.cancel()
+}
+
+// CancelRequested tells if the user pressed Cancel or Escape this frame
+func (s *BackgroundSearch) CancelRequested() bool {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		return true
+	}
+	return inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && image.Pt(ebiten.CursorPosition()).In(cancelSearchButtonRect)
+}
+
+// Draw shows the progress of the search over the board
+func (s *BackgroundSearch) Draw(screen *ebiten.Image) {
+	width := screen.Bounds().Dx()
+	ebitenutil.DrawRect(screen, 0, 0, float64(width), MaxSize*TileSize, color.NRGBA{0, 0, 0, 150})
+	// A spinner, so a search that expands nothing for a while still looks alive
+	dots := int(time.Since(s.started)/(300*time.Millisecond)) % 4
+	label := i18n.T("game.running", i18n.Algorithm(s.algorithm)) + "..."[:dots]
+	progress := fmt.Sprintf("%d nodes expanded in %.1fs", s.expanded.Load(), time.Since(s.started).Seconds())
+	ebitenutil.DebugPrintAt(screen, label, width/2-100, MaxSize*TileSize/2-20)
+	ebitenutil.DebugPrintAt(screen, progress, width/2-100, MaxSize*TileSize/2)
+
+	ebitenutil.DrawRect(screen, float64(cancelSearchButtonRect.Min.X), float64(cancelSearchButtonRect.Min.Y), float64(cancelSearchButtonRect.Dx()), float64(cancelSearchButtonRect.Dy()), color.RGBA{255, 0, 0, 255})
+	ebitenutil.DebugPrintAt(screen, i18n.T("game.cancel"), cancelSearchButtonRect.Min.X+40, cancelSearchButtonRect.Min.Y+12)
+}
@@ -0,0 +1,83 @@
+package game
+
+import (
+	"log"
+
+	"github.com/Krud3/InteligenciaArtificial/src/i18n"
+	"github.com/Krud3/InteligenciaArtificial/src/session"
+	"github.com/Krud3/InteligenciaArtificial/src/visuals"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Settings are the choices of this session, restored on the next launch
+func (g *Game) Settings() session.Settings {
+	settings := session.Settings{
+		Speed:        g.carSpeed,
+		Theme:        visuals.ThemeName(),
+		Language:     g.language,
+		WindowWidth:  g.windowWidth,
+		WindowHeight: g.windowHeight,
+	}
+	if g.carControls != nil {
+		settings.Speed = g.carControls.Speed()
+	}
+	if g.selectedFileIndex >= 0 && g.selectedFileIndex < len(g.files) {
+		settings.Map = g.files[g.selectedFileIndex]
+	}
+	if g.selectedAlgorithmIndex >= 0 && g.selectedAlgorithmIndex < len(g.algorithms) {
+		settings.Algorithm = g.algorithms[g.selectedAlgorithmIndex]
+	}
+	return settings
+}
+
+// SaveSettings writes the settings, an error is only logged
+func (g *Game) SaveSettings() {
+	if err := session.SaveSettings(g.Settings()); err != nil {
+		log.Print(i18n.T("log.saveSettings", err))
+	}
+}
+
+// applySettings restores the choices of the last session that are still valid
+func (g *Game) applySettings(settings session.Settings) {
+	visuals.SetTheme(settings.Theme)
+	if settings.Speed >= 0 && settings.Speed < len(carDelays) {
+		g.carSpeed = settings.Speed
+	}
+	g.windowWidth, g.windowHeight = settings.WindowWidth, settings.WindowHeight
+	g.language = settings.Language
+	for i, file := range g.files {
+		if file == settings.Map {
+			g.selectedFileIndex = i
+		}
+	}
+	for _, algorithmType := range []AlgorithmType{InformedAlgorithm, UninformedAlgorithm} {
+		g.SetAlgorithmType(algorithmType)
+		for i, algorithm := range g.algorithms {
+			if algorithm == settings.Algorithm {
+				g.selectedAlgorithmIndex = i
+				return
+			}
+		}
+	}
+	// The algorithm isn't known, back to the informed ones
+	g.SetAlgorithmType(InformedAlgorithm)
+}
+
+// WindowSize is the size of the window of the last session, zero if unknown
+func (g *Game) WindowSize() (int, int) {
+	return g.windowWidth, g.windowHeight
+}
+
+// NextLanguage switches the texts to the next language, the screens are
+// built again since their labels are set when they're created
+func (g *Game) NextLanguage() {
+	i18n.Next()
+	g.language = string(i18n.Current())
+	g.menu = newMenu(g)
+	g.endScreen = newEndScreen(g)
+	g.history = newHistoryScreen(g)
+	// Pressing Enter again switches back
+	g.menu.playground.Focus(g.menu.language)
+	ebiten.SetWindowTitle(i18n.T("game.windowTitle"))
+	g.SaveSettings()
+}
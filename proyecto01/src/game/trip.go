@@ -0,0 +1,220 @@
+package game
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+
+	"github.com/Krud3/InteligenciaArtificial/src/datatypes"
+	"github.com/Krud3/InteligenciaArtificial/src/i18n"
+	"github.com/Krud3/InteligenciaArtificial/src/input"
+	"github.com/Krud3/InteligenciaArtificial/src/searchAlgorithms"
+	"github.com/Krud3/InteligenciaArtificial/src/visuals"
+	"github.com/Krud3/InteligenciaArtificial/src/visuals/components"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+const (
+	// Frames the passenger takes to get off and walk away from the car
+	dropOffFrames   = 60
+	tripPanelWidth  = 420
+	tripPanelHeight = 330
+)
+
+var (
+	tripRouteColor = color.RGBA{0, 160, 255, 200}
+	tripPanelColor = color.RGBA{40, 40, 40, 230}
+)
+
+// tripClass groups the cells of the route that cost the same
+type tripClass struct {
+	label string
+	cells int
+	cost  float32
+}
+
+// TripSummary is shown when the car leaves the passenger at the goal. The
+// passenger gets off first, then the panel shows what the trip cost by kind
+// of traffic and lets the user replay it or retry with another algorithm
+type TripSummary struct {
+	algorithm  string
+	route      []datatypes.BoardCoordinate
+	start      datatypes.BoardCoordinate
+	classes    []tripClass
+	total      float32
+	turns      int
+	frame      int // Frames since the car arrived
+	panel      image.Rectangle
+	playground components.Playground
+}
+
+// newTripSummary measures the path of the car, the cell it starts on costs nothing
+func newTripSummary(g *Game, viewport image.Rectangle) *TripSummary {
+	trip := &TripSummary{
+		algorithm: g.currentAlgorithm,
+		start:     datatypes.BoardCoordinate{X: g.car.InitialPosY, Y: g.car.InitialPosX},
+		classes: []tripClass{
+			{label: i18n.T("trip.freeRoad")},
+			{label: i18n.T("trip.medium")},
+			{label: i18n.T("trip.heavy")},
+		},
+	}
+	for _, cell := range g.car.Path {
+		coordinate := datatypes.BoardCoordinate{X: cell[0], Y: cell[1]}
+		if len(trip.route) == 0 && coordinate == trip.start {
+			continue
+		}
+		trip.route = append(trip.route, coordinate)
+		value := Matrix.Matrix[coordinate.X][coordinate.Y]
+		class := &trip.classes[0]
+		switch value {
+		case searchAlgorithms.MIDCOST:
+			class = &trip.classes[1]
+		case searchAlgorithms.HEAVYCOST:
+			class = &trip.classes[2]
+		}
+		class.cells++
+		class.cost += searchAlgorithms.CellCost(value)
+		trip.total += searchAlgorithms.CellCost(value)
+	}
+	trip.turns = countTurns(append([]datatypes.BoardCoordinate{trip.start}, trip.route...))
+
+	center := image.Pt(viewport.Min.X+viewport.Dx()/2, viewport.Min.Y+viewport.Dy()/2)
+	trip.panel = image.Rect(center.X-tripPanelWidth/2, center.Y-tripPanelHeight/2, center.X+tripPanelWidth/2, center.Y+tripPanelHeight/2)
+	trip.build(g)
+	return trip
+}
+
+// countTurns counts the changes of direction along a route, going back the
+// way it came counts as a turn too
+func countTurns(route []datatypes.BoardCoordinate) int {
+	turns := 0
+	var last image.Point
+	for i := 1; i < len(route); i++ {
+		direction := image.Pt(route[i].Y-route[i-1].Y, route[i].X-route[i-1].X)
+		if i > 1 && direction != last {
+			turns++
+		}
+		last = direction
+	}
+	return turns
+}
+
+func (t *TripSummary) build(g *Game) {
+	column := components.Stack{X: t.panel.Min.X + 20, Y: t.panel.Min.Y + 15, Width: t.panel.Dx() - 40, Gap: 10}
+	title := &components.Label{Pos: column.Next(16).Min, Text: i18n.T("trip.title", i18n.Algorithm(t.algorithm))}
+
+	table := components.NewTable(column.Next(4*25).Min, components.Column{Title: i18n.T("trip.traffic"), Width: 180}, components.Column{Title: i18n.T("trip.cells"), Width: 90}, components.Column{Title: i18n.T("common.cost"), Width: 110})
+	rows := make([]components.TableRow, 0, len(t.classes)+1)
+	for _, class := range t.classes {
+		rows = append(rows, components.TableRow{Cells: []string{class.label, fmt.Sprint(class.cells), fmt.Sprintf("%.0f", class.cost)}})
+	}
+	table.SetRows(rows)
+	totals := &components.Label{Pos: column.Next(16).Min, Text: i18n.T("trip.totals", t.total, len(t.route), t.turns)}
+
+	actions := components.Columns(column.Next(40), 3, 10)
+	replay := &components.Button{Rect: actions[0], Label: i18n.T("trip.replay"), Color: visuals.Green, OnClick: g.ReplayTrip}
+	stats := &components.Button{Rect: actions[1], Label: i18n.T("trip.searchStats"), Color: visuals.Grey, OnClick: func() { g.state = EndState }}
+	back := &components.Button{Rect: actions[2], Label: i18n.T("common.backToMenu"), Color: visuals.Red, OnClick: func() { g.state = MenuState }}
+
+	retryLabel := &components.Label{Pos: column.Next(16).Min, Text: i18n.T("trip.retry")}
+	t.playground.Add(title, table, totals, replay, stats, back, retryLabel)
+	var others []string
+	for _, algorithm := range searchAlgorithms.AlgorithmNames {
+		if algorithm != t.algorithm {
+			others = append(others, algorithm)
+		}
+	}
+	if len(others) > 0 {
+		for i, rect := range components.Columns(column.Next(40), len(others), 6) {
+			algorithm := others[i]
+			t.playground.Add(&components.Button{Rect: rect, Label: i18n.Algorithm(algorithm), Color: visuals.Orange, OnClick: func() { g.RetryTrip(algorithm) }})
+		}
+	}
+	t.playground.Focus(replay)
+}
+
+// droppingOff is true while the passenger gets off, the panel waits for it
+func (t *TripSummary) droppingOff() bool {
+	return t.frame < dropOffFrames
+}
+
+func (t *TripSummary) Update() {
+	if t.droppingOff() {
+		t.frame++
+		return
+	}
+	t.playground.Update()
+}
+
+// DrawWorld draws the route and the passenger walking away from the goal on the board
+func (t *TripSummary) DrawWorld(world *ebiten.Image, scene *Scene) {
+	drawRouteLine(world, t.route, t.start, tripRouteColor, 0)
+	if !t.droppingOff() {
+		return
+	}
+	progress := float64(t.frame) / dropOffFrames
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(float64(scene.GoalPosX*TileSize)+progress*TileSize/2, float64(scene.GoalPosY*TileSize)-progress*TileSize/2)
+	op.ColorScale.ScaleAlpha(float32(1 - progress))
+	world.DrawImage(scene.Images[Passenger], op)
+}
+
+// Draw draws the panel over the board once the passenger got off
+func (t *TripSummary) Draw(screen *ebiten.Image) {
+	if t.droppingOff() {
+		ebitenutil.DebugPrintAt(screen, i18n.T("trip.droppingOff"), t.panel.Min.X+20, t.panel.Min.Y)
+		return
+	}
+	ebitenutil.DrawRect(screen, float64(t.panel.Min.X), float64(t.panel.Min.Y), float64(t.panel.Dx()), float64(t.panel.Dy()), tripPanelColor)
+	t.playground.Draw(screen)
+}
+
+// tripFinished is true when the car is at the goal at the end of its path
+// with the passenger on board
+func (g *Game) tripFinished() bool {
+	return len(g.car.Path) > 0 && g.car.Index >= len(g.car.Path) && g.passenger == nil &&
+		g.car.PosX == g.scene.GoalPosX && g.car.PosY == g.scene.GoalPosY
+}
+
+// finishTrip leaves the passenger at the goal and shows the summary
+func (g *Game) finishTrip() {
+	g.car.SetImageWithoutPassenger()
+	g.trip = newTripSummary(g, g.boardViewport())
+	g.state = TripState
+}
+
+func (g *Game) UpdateTrip() {
+	g.camera.SetViewport(g.boardViewport())
+	g.camera.Update(nil)
+	if input.Pressed(ebiten.KeyEscape) {
+		g.state = MenuState
+		return
+	}
+	g.trip.Update()
+}
+
+func (g *Game) DrawTrip(screen *ebiten.Image) {
+	g.world.Clear()
+	g.scene.Draw(g.world)
+	g.trip.DrawWorld(g.world, g.scene)
+	g.world.DrawImage(g.car.Image, g.car.DrawOptions(TileSize))
+	g.camera.Draw(screen, g.world)
+	g.trip.Draw(screen)
+}
+
+// ReplayTrip drives the same route again from the start
+func (g *Game) ReplayTrip() {
+	g.car.Seek(0)
+	g.car.Paused = false
+	g.trip = nil
+	g.state = PlayingState
+}
+
+// RetryTrip searches the same map again with another algorithm
+func (g *Game) RetryTrip(algorithm string) {
+	g.car.Seek(0)
+	g.trip = nil
+	g.SetCarPath(algorithm)
+}
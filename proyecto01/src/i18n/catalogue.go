@@ -0,0 +1,274 @@
+package i18n
+
+// translation of a text, the Spanish one can be left empty to use the English one
+type translation struct {
+	en, es string
+}
+
+// catalogue has every text, by key. The keys start with the screen or the
+// package that shows them
+var catalogue = map[string]translation{
+	"language.name": {en: "English", es: "Español"},
+
+	// Algorithms, by the name they're run with
+	"algorithm.Avaro":                   {en: "Greedy", es: "Avaro"},
+	"algorithm.A*":                      {en: "A*", es: "A*"},
+	"algorithm.Breadth First Algorithm": {en: "Breadth First Search", es: "Búsqueda en amplitud"},
+	"algorithm.DepthSearch":             {en: "Depth First Search", es: "Búsqueda en profundidad"},
+	"algorithm.Uniform Cost Search":     {en: "Uniform Cost Search", es: "Costo uniforme"},
+
+	// Status of a search
+	"status.solved":                  {en: "solved", es: "resuelto"},
+	"status.cancelled":               {en: "cancelled", es: "cancelado"},
+	"status.time limit reached":      {en: "time limit reached", es: "límite de tiempo"},
+	"status.expansion limit reached": {en: "expansion limit reached", es: "límite de nodos"},
+	"status.memory limit reached":    {en: "memory limit reached", es: "límite de memoria"},
+	"status.no solution":             {en: "no solution", es: "sin solución"},
+
+	// Shared by several screens
+	"common.backToMenu":   {en: "Back to Menu", es: "Volver al menú"},
+	"common.returnToMenu": {en: "Return to Menu", es: "Volver al menú"},
+	"common.algorithm":    {en: "Algorithm", es: "Algoritmo"},
+	"common.cost":         {en: "Cost", es: "Costo"},
+	"common.status":       {en: "Status", es: "Estado"},
+	"common.timeMs":       {en: "Time (ms)", es: "Tiempo (ms)"},
+	"common.replay":       {en: "Replay", es: "Repetir"},
+	"common.retry":        {en: "Retry", es: "Reintentar"},
+
+	// Menu
+	"menu.upload":     {en: "Upload Matrix", es: "Subir matriz"},
+	"menu.drive":      {en: "Drive Yourself", es: "Conducir"},
+	"menu.editor":     {en: "Map Editor", es: "Editor de mapas"},
+	"menu.informed":   {en: "Informed Search", es: "Búsqueda informada"},
+	"menu.uninformed": {en: "Uninformed Search", es: "Búsqueda no informada"},
+	"menu.compare":    {en: "Compare\nCtrl+click to pick", es: "Comparar\nCtrl+clic para elegir"},
+	"menu.start":      {en: "Start Game", es: "Iniciar juego"},
+	"menu.history":    {en: "History", es: "Historial"},
+	"menu.theme":      {en: "Theme: %s", es: "Tema: %s"},
+
+	// Report of the last search
+	"end.title":   {en: "Algorithm Execution Report", es: "Informe de ejecución del algoritmo"},
+	"end.metric":  {en: "Metric", es: "Métrica"},
+	"end.value":   {en: "Value", es: "Valor"},
+	"end.nodes":   {en: "Nodes Expanded", es: "Nodos expandidos"},
+	"end.depth":   {en: "Tree Depth", es: "Profundidad del árbol"},
+	"end.time":    {en: "Computation Time", es: "Tiempo de cómputo"},
+	"end.seconds": {en: "%.2f seconds", es: "%.2f segundos"},
+	"end.cost":    {en: "Solution Cost", es: "Costo de la solución"},
+
+	// Game
+	"game.stats":       {en: "Game Stats", es: "Estadísticas"},
+	"game.running":     {en: "Running %s", es: "Ejecutando %s"},
+	"game.cancel":      {en: "Cancel", es: "Cancelar"},
+	"game.pause":       {en: "Pause", es: "Pausa"},
+	"game.play":        {en: "Play", es: "Seguir"},
+	"game.skip":        {en: "Skip", es: "Saltar"},
+	"game.expanded":    {en: "Expanded %d/%d at %.0f/s", es: "Expandidos %d/%d a %.0f/s"},
+	"game.windowTitle": {en: "DidIA Game", es: "Juego DidIA"},
+
+	// Summary of a finished trip
+	"trip.title":       {en: "Trip finished with %s", es: "Viaje terminado con %s"},
+	"trip.freeRoad":    {en: "Free road", es: "Vía libre"},
+	"trip.medium":      {en: "Medium traffic", es: "Tráfico medio"},
+	"trip.heavy":       {en: "Heavy traffic", es: "Tráfico pesado"},
+	"trip.traffic":     {en: "Traffic", es: "Tráfico"},
+	"trip.cells":       {en: "Cells", es: "Casillas"},
+	"trip.totals":      {en: "Total cost %.0f   Steps %d   Turns %d", es: "Costo total %.0f   Pasos %d   Giros %d"},
+	"trip.replay":      {en: "Replay Route", es: "Repetir ruta"},
+	"trip.searchStats": {en: "Search Stats", es: "Estadísticas"},
+	"trip.retry":       {en: "Retry with another algorithm", es: "Reintentar con otro algoritmo"},
+	"trip.droppingOff": {en: "Dropping off the passenger...", es: "Dejando a la pasajera..."},
+
+	// Comparison
+	"compare.title":    {en: "Algorithm Comparison", es: "Comparación de algoritmos"},
+	"compare.expanded": {en: "Expanded", es: "Expandidos"},
+	"compare.depth":    {en: "Depth", es: "Profundidad"},
+	"compare.table":    {en: "Table", es: "Tabla"},
+	"compare.cars":     {en: "Cars", es: "Carros"},
+	"compare.step":     {en: "%s  step %d/%d", es: "%s  paso %d/%d"},
+
+	// Driving mode
+	"drive.status":    {en: "Cost %.0f  Steps %d", es: "Costo %.0f  Pasos %d"},
+	"drive.pickUp":    {en: "  Pick up the passenger", es: "  Recoge a la pasajera"},
+	"drive.toGoal":    {en: "  Drive to the goal", es: "  Conduce a la meta"},
+	"drive.help":      {en: "Arrow keys to drive, R to restart", es: "Flechas para conducir, R para reiniciar"},
+	"drive.noOptimal": {en: "No optimal route to compare with", es: "No hay ruta óptima para comparar"},
+	"drive.score":     {en: "Optimal (%s): cost %.0f, %d steps. Score %.0f%%", es: "Óptima (%s): costo %.0f, %d pasos. Puntaje %.0f%%"},
+
+	// Map editor
+	"editor.road":      {en: "Road", es: "Vía"},
+	"editor.wall":      {en: "Wall", es: "Muro"},
+	"editor.start":     {en: "Start", es: "Inicio"},
+	"editor.medium":    {en: "Medium", es: "Medio"},
+	"editor.heavy":     {en: "Heavy", es: "Pesado"},
+	"editor.passenger": {en: "Passenger", es: "Pasajera"},
+	"editor.goal":      {en: "Goal", es: "Meta"},
+	"editor.undo":      {en: "Undo", es: "Deshacer"},
+	"editor.redo":      {en: "Redo", es: "Rehacer"},
+	"editor.save":      {en: "Save", es: "Guardar"},
+	"editor.back":      {en: "Back", es: "Volver"},
+	"editor.status":    {en: "%dx%d  brush: %s", es: "%dx%d  pincel: %s"},
+	"editor.cantSave":  {en: "Can't save: %v", es: "No se puede guardar: %v"},
+	"editor.saved":     {en: "Saved as %s", es: "Guardado como %s"},
+
+	// History
+	"history.title":        {en: "Run History", es: "Historial de ejecuciones"},
+	"history.when":         {en: "When", es: "Cuándo"},
+	"history.map":          {en: "Map", es: "Mapa"},
+	"history.nodes":        {en: "Nodes", es: "Nodos"},
+	"history.newer":        {en: "Newer", es: "Recientes"},
+	"history.older":        {en: "Older", es: "Anteriores"},
+	"history.export":       {en: "Export CSV", es: "Exportar CSV"},
+	"history.exportTitle":  {en: "Export the Run History", es: "Exportar el historial"},
+	"history.csvFiles":     {en: "CSV Files", es: "Archivos CSV"},
+	"history.exportFailed": {en: "Export failed: %v", es: "Falló la exportación: %v"},
+	"history.exported":     {en: "Exported to %s", es: "Exportado a %s"},
+	"history.empty":        {en: "No searches yet, the runs of the game and the command line show up here", es: "Aún no hay búsquedas, aquí aparecen las del juego y la línea de comandos"},
+	"history.page":         {en: "Page %d of %d, %d runs", es: "Página %d de %d, %d ejecuciones"},
+
+	// Upload dialog
+	"upload.title":       {en: "Select a Matrix File", es: "Selecciona un archivo de matriz"},
+	"upload.matrixFiles": {en: "Matrix Files", es: "Archivos de matriz"},
+	"upload.textFiles":   {en: "Text Files", es: "Archivos de texto"},
+	"upload.pngMaps":     {en: "PNG Maps", es: "Mapas PNG"},
+	"upload.copied":      {en: "File copied to %s successfully.", es: "Archivo copiado a %s."},
+	"upload.canceled":    {en: "No file selected or operation was canceled", es: "No se seleccionó ningún archivo o se canceló"},
+
+	// Messages of the log
+	"log.loadMap":         {en: "Error loading %s: %v", es: "Error al cargar %s: %v"},
+	"log.driving":         {en: "Error starting the driving mode: %v", es: "Error al iniciar el modo de conducción: %v"},
+	"log.comparison":      {en: "Error starting the comparison: %v", es: "Error al iniciar la comparación: %v"},
+	"log.editor":          {en: "Error opening the editor: %v", es: "Error al abrir el editor: %v"},
+	"log.passenger":       {en: "Error creating the passenger: %v", es: "Error al crear la pasajera: %v"},
+	"log.selectFile":      {en: "Error selecting file: %v", es: "Error al seleccionar el archivo: %v"},
+	"log.mapDir":          {en: "Error creating map directory: %v", es: "Error al crear el directorio de mapas: %v"},
+	"log.importImage":     {en: "Error importing image: %v", es: "Error al importar la imagen: %v"},
+	"log.saveMatrix":      {en: "Error saving matrix: %v", es: "Error al guardar la matriz: %v"},
+	"log.copyFile":        {en: "Error copying file: %v", es: "Error al copiar el archivo: %v"},
+	"log.listMaps":        {en: "Error listing the maps: %v", es: "Error al listar los mapas: %v"},
+	"log.search":          {en: "Error running the search: %v", es: "Error al ejecutar la búsqueda: %v"},
+	"log.runAlgorithm":    {en: "Error running %s: %v", es: "Error al ejecutar %s: %v"},
+	"log.readHistory":     {en: "Error reading the history: %v", es: "Error al leer el historial: %v"},
+	"log.recordRun":       {en: "Error recording the run: %v", es: "Error al registrar la ejecución: %v"},
+	"log.saveSettings":    {en: "Error saving the settings: %v", es: "Error al guardar la configuración: %v"},
+	"log.loadSettings":    {en: "Error loading the settings, using the defaults: %v", es: "Error al cargar la configuración, se usan los valores por defecto: %v"},
+	"log.openFile":        {en: "Error opening file: %s; error: %s", es: "Error al abrir el archivo: %s; error: %s"},
+	"log.unknownStrategy": {en: "Unknown strategy", es: "Estrategia desconocida"},
+	"log.noInit":          {en: "Initial position not found", es: "No se encontró la posición inicial"},
+
+	// Command line
+	"cli.mapSaved":        {en: "Map saved to %s", es: "Mapa guardado en %s"},
+	"cli.historyExported": {en: "History exported to %s", es: "Historial exportado a %s"},
+	"cli.status":          {en: "Status: %s", es: "Estado: %s"},
+	"flag.cmd":            {en: "interface option", es: "usar la línea de comandos en vez de la ventana"},
+	"flag.import":         {en: "PNG map to convert into a battery file", es: "mapa PNG que se convierte en un archivo de la batería"},
+	"flag.block":          {en: "pixels per cell side of the imported PNG", es: "píxeles por lado de cada casilla del PNG importado"},
+	"flag.palette":        {en: "colour key of the imported PNG, as rrggbb=value,...", es: "colores del PNG importado, como rrggbb=valor,..."},
+	"flag.tolerance":      {en: "per-channel colour tolerance of the imported PNG", es: "tolerancia de color por canal del PNG importado"},
+	"flag.map":            {en: "battery file searched in cmd mode", es: "archivo de la batería que se busca en modo cmd"},
+	"flag.algorithm":      {en: "algorithm used in cmd mode", es: "algoritmo usado en modo cmd"},
+	"flag.png":            {en: "write the map and the solution to a PNG file (cmd mode)", es: "escribir el mapa y la solución en un PNG (modo cmd)"},
+	"flag.svg":            {en: "write the map and the solution to an SVG file (cmd mode)", es: "escribir el mapa y la solución en un SVG (modo cmd)"},
+	"flag.tile":           {en: "pixels per cell of the rendered images", es: "píxeles por casilla de las imágenes"},
+	"flag.flat":           {en: "render flat colours instead of the game sprites", es: "dibujar colores planos en vez de los sprites del juego"},
+	"flag.explored":       {en: "mark the cells expanded by the search in the rendered images", es: "marcar en las imágenes las casillas expandidas por la búsqueda"},
+	"flag.trace":          {en: "print every event of the search (cmd mode)", es: "imprimir cada evento de la búsqueda (modo cmd)"},
+	"flag.dot":            {en: "write the search tree to a Graphviz DOT file (cmd mode)", es: "escribir el árbol de búsqueda en un archivo DOT de Graphviz (modo cmd)"},
+	"flag.dotDepth":       {en: "maximum depth of the exported search tree, 0 for no limit", es: "profundidad máxima del árbol exportado, 0 sin límite"},
+	"flag.dotNodes":       {en: "maximum nodes of the exported search tree, 0 for no limit", es: "nodos máximos del árbol exportado, 0 sin límite"},
+	"flag.gif":            {en: "write an animation of the car following the solution to a GIF file (cmd mode)", es: "escribir en un GIF la animación del carro siguiendo la solución (modo cmd)"},
+	"flag.delay":          {en: "time each frame of the GIF is shown", es: "tiempo que se muestra cada cuadro del GIF"},
+	"flag.scale":          {en: "size multiplier of the GIF frames", es: "multiplicador del tamaño de los cuadros del GIF"},
+	"flag.timeout":        {en: "stop the search after this time, 0 for no limit (cmd mode)", es: "detener la búsqueda tras este tiempo, 0 sin límite (modo cmd)"},
+	"flag.maxNodes":       {en: "stop the search after expanding this many nodes, 0 for no limit (cmd mode)", es: "detener la búsqueda tras expandir estos nodos, 0 sin límite (modo cmd)"},
+	"flag.maxMemory":      {en: "stop the search when the heap grows this many MB, 0 for no limit (cmd mode)", es: "detener la búsqueda cuando el heap crezca estos MB, 0 sin límite (modo cmd)"},
+	"flag.maps":           {en: "directory of the user maps, defaults to $%s or the battery folder", es: "directorio de los mapas del usuario, por defecto $%s o la carpeta battery"},
+	"flag.session":        {en: "directory of the settings and the run history, defaults to $%s or the user config", es: "directorio de la configuración y el historial, por defecto $%s o la configuración del usuario"},
+	"flag.historyCSV":     {en: "export the run history to a CSV file and exit", es: "exportar el historial a un archivo CSV y salir"},
+	"flag.lang":           {en: "language of the texts, en or es, defaults to the settings or $%s and the locale", es: "idioma de los textos, en o es, por defecto el de la configuración o $%s y el locale"},
+	"flag.validate":       {en: "check a path file against -map, one row and column per line, and exit", es: "revisar un archivo de camino contra -map, una fila y columna por línea, y salir"},
+	"flag.savePath":       {en: "write the path of the solution to a file that -validate reads (cmd mode)", es: "escribir el camino de la solución en un archivo que lee -validate (modo cmd)"},
+	"flag.bench":          {en: "run every algorithm over every map, check their solutions and exit", es: "correr cada algoritmo sobre cada mapa, revisar sus soluciones y salir"},
+	"flag.lenient":        {en: "read the maps leniently: skip comments, pad short rows and turn unknown cells into walls", es: "leer los mapas con tolerancia: saltar comentarios, rellenar filas cortas y convertir casillas desconocidas en muros"},
+
+	// Directions of a route
+	"directions.title":      {en: "Directions", es: "Indicaciones"},
+	"directions.toggle":     {en: "Directions (D)", es: "Indicaciones (D)"},
+	"directions.up":         {en: "up", es: "hacia arriba"},
+	"directions.right":      {en: "right", es: "a la derecha"},
+	"directions.down":       {en: "down", es: "hacia abajo"},
+	"directions.left":       {en: "left", es: "a la izquierda"},
+	"directions.start":      {en: "Head %s %s %s (cost %.0f)", es: "Sal %s %s %s (costo %.0f)"},
+	"directions.straight":   {en: "Continue %s %s %s (cost %.0f)", es: "Sigue %s %s %s (costo %.0f)"},
+	"directions.turnRight":  {en: "Turn right and go %[2]s %[3]s (cost %.0[4]f)", es: "Gira a la derecha y ve %[2]s %[3]s (costo %.0[4]f)"},
+	"directions.turnLeft":   {en: "Turn left and go %[2]s %[3]s (cost %.0[4]f)", es: "Gira a la izquierda y ve %[2]s %[3]s (costo %.0[4]f)"},
+	"directions.turnAround": {en: "Turn around and go %[2]s %[3]s (cost %.0[4]f)", es: "Da la vuelta y ve %[2]s %[3]s (costo %.0[4]f)"},
+	"directions.block":      {en: "1 block", es: "1 cuadra"},
+	"directions.blocks":     {en: "%d blocks", es: "%d cuadras"},
+	"directions.free":       {en: "on free road", es: "por vía libre"},
+	"directions.medium":     {en: "through medium traffic", es: "con tráfico medio"},
+	"directions.heavy":      {en: "through heavy traffic", es: "con tráfico pesado"},
+	"directions.pickUp":     {en: "Pick up the passenger", es: "Recoge a la pasajera"},
+	"directions.arrive":     {en: "Drop off the passenger at the goal, total cost %.0f", es: "Deja a la pasajera en la meta, costo total %.0f"},
+
+	// Validator of the solutions and benchmark
+	"validator.noMainCells":  {en: "the map has no start, passenger or goal", es: "el mapa no tiene inicio, pasajera o meta"},
+	"validator.empty":        {en: "the path has no moves", es: "el camino no tiene movimientos"},
+	"validator.outside":      {en: "move %d goes to (%d, %d), outside the map", es: "el movimiento %d va a (%d, %d), fuera del mapa"},
+	"validator.notAdjacent":  {en: "move %d jumps from (%d, %d) to (%d, %d)", es: "el movimiento %d salta de (%d, %d) a (%d, %d)"},
+	"validator.wall":         {en: "move %d enters the wall at (%d, %d)", es: "el movimiento %d entra al muro en (%d, %d)"},
+	"validator.noPassenger":  {en: "the passenger is never picked up before the goal", es: "la pasajera nunca se recoge antes de la meta"},
+	"validator.notAtGoal":    {en: "the path ends at (%d, %d), not at the goal", es: "el camino termina en (%d, %d), no en la meta"},
+	"validator.badLine":      {en: "line %d of the path: %q is not a row and a column", es: "línea %d del camino: %q no es una fila y una columna"},
+	"validator.valid":        {en: "Valid path: %d moves, cost %.0f", es: "Camino válido: %d movimientos, costo %.0f"},
+	"validator.invalid":      {en: "Invalid path:", es: "Camino inválido:"},
+	"validator.checkedCost":  {en: "Checked cost: %.0f", es: "Costo verificado: %.0f"},
+	"validator.costMismatch": {en: "WARNING: %s reported cost %.0f but the path costs %.0f", es: "AVISO: %s reportó costo %.0f pero el camino cuesta %.0f"},
+	"validator.pathSaved":    {en: "Path saved to %s", es: "Camino guardado en %s"},
+	"bench.map":              {en: "MAP", es: "MAPA"},
+	"bench.algorithm":        {en: "ALGORITHM", es: "ALGORITMO"},
+	"bench.status":           {en: "STATUS", es: "ESTADO"},
+	"bench.cost":             {en: "COST", es: "COSTO"},
+	"bench.checked":          {en: "CHECKED", es: "VERIFICADO"},
+	"bench.nodes":            {en: "NODES", es: "NODOS"},
+	"bench.time":             {en: "TIME (ms)", es: "TIEMPO (ms)"},
+	"bench.verdict":          {en: "CHECK", es: "REVISIÓN"},
+	"bench.ok":               {en: "ok", es: "ok"},
+	"bench.mismatch":         {en: "COST MISMATCH", es: "COSTO DISTINTO"},
+	"bench.invalid":          {en: "INVALID: %s", es: "INVÁLIDO: %s"},
+	"bench.summary":          {en: "%d runs, %d cost mismatches, %d invalid paths", es: "%d ejecuciones, %d costos distintos, %d caminos inválidos"},
+
+	// Errors
+	"error.unknownLanguage":      {en: "unknown language %q, use en or es", es: "idioma desconocido %q, usa en o es"},
+	"error.decode":               {en: "error decoding %s: %w", es: "error al decodificar %s: %w"},
+	"error.emptyMatrix":          {en: "the matrix is empty", es: "la matriz está vacía"},
+	"error.rowColumns":           {en: "row %d must have %d columns, but it has %d", es: "la fila %d debe tener %d columnas, pero tiene %d"},
+	"error.environment":          {en: "environment must have init, dog, and goal positions", es: "el entorno debe tener posiciones de inicio, pasajera y meta"},
+	"error.unknownAlgorithm":     {en: "unknown algorithm %q", es: "algoritmo desconocido %q"},
+	"error.emptyMap":             {en: "the map is empty", es: "el mapa está vacío"},
+	"error.blankLine":            {en: "line %d is blank, only the end of the map can have blank lines", es: "la línea %d está vacía, solo el final del mapa puede tener líneas vacías"},
+	"error.tooManyRows":          {en: "the map has more than %d rows", es: "el mapa tiene más de %d filas"},
+	"error.tooManyColumns":       {en: "line %d has more than %d columns", es: "la línea %d tiene más de %d columnas"},
+	"error.notCellValue":         {en: "line %d: %q is not a cell value", es: "línea %d: %q no es un valor de casilla"},
+	"error.mapTooBig":            {en: "the map file is bigger than %d bytes", es: "el archivo del mapa pesa más de %d bytes"},
+	"error.rowLength":            {en: "row %d has %d columns, expected %d", es: "la fila %d tiene %d columnas, se esperaban %d"},
+	"error.cellValue":            {en: "unknown cell value %d at (%d, %d)", es: "valor de casilla desconocido %d en (%d, %d)"},
+	"error.lineLength":           {en: "line %d has %d columns, expected %d", es: "la línea %d tiene %d columnas, se esperaban %d"},
+	"error.lineCellValue":        {en: "line %d: unknown cell value %d in column %d", es: "línea %d: valor de casilla desconocido %d en la columna %d"},
+	"error.boardTooBig":          {en: "the map is %dx%d, the game draws maps of up to %d cells per side", es: "el mapa es de %dx%d, el juego dibuja mapas de hasta %d casillas por lado"},
+	"error.exactlyOne":           {en: "the map must have exactly one %s, it has %d", es: "el mapa debe tener exactamente un(a) %s, tiene %d"},
+	"error.passengerUnreachable": {en: "the passenger can't be reached from the start", es: "la pasajera no es alcanzable desde el inicio"},
+	"error.goalUnreachable":      {en: "the goal can't be reached from the start", es: "la meta no es alcanzable desde el inicio"},
+	"error.paletteEntry":         {en: "invalid palette entry %q, expected rrggbb=value", es: "entrada de paleta inválida %q, se esperaba rrggbb=valor"},
+	"error.paletteColour":        {en: "invalid colour %q in palette", es: "color inválido %q en la paleta"},
+	"error.paletteValue":         {en: "invalid cell value %q in palette", es: "valor de casilla inválido %q en la paleta"},
+	"error.emptyPalette":         {en: "empty palette", es: "paleta vacía"},
+	"error.blockSize":            {en: "image size %dx%d is not a multiple of the block size %d", es: "el tamaño de la imagen %dx%d no es múltiplo del tamaño de bloque %d"},
+	"error.colourNotInPalette":   {en: "colour #%02x%02x%02x at cell (%d, %d) is not in the palette", es: "el color #%02x%02x%02x de la casilla (%d, %d) no está en la paleta"},
+	"error.imageTooBig":          {en: "the image makes a map of %dx%d cells, the limit is %dx%d", es: "la imagen da un mapa de %dx%d casillas, el límite es %dx%d"},
+
+	// Names of the special cells in the errors
+	"cell.start":     {en: "start", es: "inicio"},
+	"cell.passenger": {en: "passenger", es: "pasajera"},
+	"cell.goal":      {en: "goal", es: "meta"},
+}
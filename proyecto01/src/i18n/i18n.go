@@ -0,0 +1,137 @@
+// Package i18n translates the texts shown to the user, in the game, the
+// command line and the errors. Every text has a key in the catalogue with
+// its English and Spanish versions, English is used if the language is unknown
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+type Language string
+
+const (
+	English Language = "en"
+	Spanish Language = "es"
+)
+
+// Languages that can be picked, in the order the game cycles through them
+var Languages = []Language{English, Spanish}
+
+// LanguageEnv is the environment variable that picks the language, before
+// the usual locale variables
+const LanguageEnv = "IA_LANG"
+
+var current = English
+
+// Parse returns the language of a name like "es", "ES" or "es_CO.UTF-8",
+// false if it isn't one of the Languages
+func Parse(name string) (Language, bool) {
+	name = strings.ToLower(name)
+	for _, language := range Languages {
+		if strings.HasPrefix(name, string(language)) {
+			return language, true
+		}
+	}
+	return English, false
+}
+
+// Detect returns the language of LanguageEnv, LC_ALL, LC_MESSAGES or LANG,
+// the first one set to a known language, or English
+func Detect() Language {
+	for _, variable := range []string{LanguageEnv, "LC_ALL", "LC_MESSAGES", "LANG"} {
+		if language, ok := Parse(os.Getenv(variable)); ok {
+			return language
+		}
+	}
+	return English
+}
+
+// SetLanguage changes the language of the texts, an unknown one is ignored
+// and returns false
+func SetLanguage(name string) bool {
+	language, ok := Parse(name)
+	if ok {
+		current = language
+	}
+	return ok
+}
+
+// Current is the language in use
+func Current() Language {
+	return current
+}
+
+// Next switches to the language after the current one
+func Next() {
+	for i, language := range Languages {
+		if language == current {
+			current = Languages[(i+1)%len(Languages)]
+			return
+		}
+	}
+}
+
+// lookup returns the text of a key in the current language, false if the
+// key isn't in the catalogue
+func lookup(key string) (string, bool) {
+	texts, ok := catalogue[key]
+	if !ok {
+		return "", false
+	}
+	if current == Spanish && texts.es != "" {
+		return texts.es, true
+	}
+	return texts.en, true
+}
+
+// T returns the text of a key in the current language, formatted with args
+// like fmt.Sprintf. A missing key is returned as it is so it shows up
+func T(key string, args ...any) string {
+	text, ok := lookup(key)
+	if !ok {
+		text = key
+	}
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
+
+// Errorf returns an error with the text of a key, like fmt.Errorf it wraps
+// the arguments formatted with %w
+func Errorf(key string, args ...any) error {
+	text, ok := lookup(key)
+	if !ok {
+		text = key
+	}
+	return fmt.Errorf(text, args...)
+}
+
+// Algorithm returns the name of an algorithm to show, the name itself is
+// the key the searches are run with
+func Algorithm(name string) string {
+	if text, ok := lookup("algorithm." + name); ok {
+		return text
+	}
+	return name
+}
+
+// Algorithms translates a list of algorithm names
+func Algorithms(names []string) []string {
+	texts := make([]string, len(names))
+	for i, name := range names {
+		texts[i] = Algorithm(name)
+	}
+	return texts
+}
+
+// Status translates the status of a search, as its String method or the
+// history write it
+func Status(status string) string {
+	if text, ok := lookup("status." + status); ok {
+		return text
+	}
+	return status
+}
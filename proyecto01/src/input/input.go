@@ -0,0 +1,76 @@
+// Package input answers what happened this frame, a key or a click that was
+// just pressed or just released, instead of what is held down. Held keys
+// repeat after a delay, like in a text field
+package input
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// Frames a key has to be held before it repeats, and frames between repeats
+const (
+	repeatDelay    = 24
+	repeatInterval = 4
+)
+
+// Pressed tells if any of the keys was just pressed
+func Pressed(keys ...ebiten.Key) bool {
+	for _, key := range keys {
+		if inpututil.IsKeyJustPressed(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// Released tells if any of the keys was just released
+func Released(keys ...ebiten.Key) bool {
+	for _, key := range keys {
+		if inpututil.IsKeyJustReleased(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// Repeated is true when the key is pressed and then every few frames while
+// it's held, for moving through lists
+func Repeated(key ebiten.Key) bool {
+	frames := inpututil.KeyPressDuration(key)
+	if frames == 1 {
+		return true
+	}
+	return frames >= repeatDelay && (frames-repeatDelay)%repeatInterval == 0
+}
+
+// Control tells if Ctrl, or Cmd on a Mac, is held
+func Control() bool {
+	return ebiten.IsKeyPressed(ebiten.KeyControl) || ebiten.IsKeyPressed(ebiten.KeyMeta)
+}
+
+func Shift() bool {
+	return ebiten.IsKeyPressed(ebiten.KeyShift)
+}
+
+// Cursor is the position of the mouse on the screen
+func Cursor() image.Point {
+	return image.Pt(ebiten.CursorPosition())
+}
+
+// Clicked tells if the left button was just pressed inside rect
+func Clicked(rect image.Rectangle) bool {
+	return inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && Cursor().In(rect)
+}
+
+// MouseReleased tells if the left button was just released, anywhere
+func MouseReleased() bool {
+	return inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft)
+}
+
+// Hovered tells if the cursor is inside rect
+func Hovered(rect image.Rectangle) bool {
+	return Cursor().In(rect)
+}
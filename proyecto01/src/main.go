@@ -1,50 +1,228 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"image"
 	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/Krud3/InteligenciaArtificial/src/directions"
 	"github.com/Krud3/InteligenciaArtificial/src/game"
+	"github.com/Krud3/InteligenciaArtificial/src/i18n"
+	"github.com/Krud3/InteligenciaArtificial/src/render"
 	"github.com/Krud3/InteligenciaArtificial/src/searchAlgorithms"
+	"github.com/Krud3/InteligenciaArtificial/src/session"
 	"github.com/Krud3/InteligenciaArtificial/src/utils"
+	"github.com/Krud3/InteligenciaArtificial/src/validator"
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
 var g *game.Game
 
 func main() {
+	// The help of the flags already follows the locale, -lang changes the rest
+	i18n.SetLanguage(string(i18n.Detect()))
 
-	cmd := flag.Bool("cmd", false, "interface option")
+	cmd := flag.Bool("cmd", false, i18n.T("flag.cmd"))
+	importImage := flag.String("import", "", i18n.T("flag.import"))
+	blockSize := flag.Int("block", 1, i18n.T("flag.block"))
+	palette := flag.String("palette", "", i18n.T("flag.palette"))
+	tolerance := flag.Int("tolerance", 0, i18n.T("flag.tolerance"))
+	mapFile := flag.String("map", "Prueba1.txt", i18n.T("flag.map"))
+	algorithm := flag.String("algorithm", searchAlgorithms.UniformCostName, i18n.T("flag.algorithm"))
+	pngFile := flag.String("png", "", i18n.T("flag.png"))
+	svgFile := flag.String("svg", "", i18n.T("flag.svg"))
+	tileSize := flag.Int("tile", 64, i18n.T("flag.tile"))
+	flatColors := flag.Bool("flat", false, i18n.T("flag.flat"))
+	showExplored := flag.Bool("explored", true, i18n.T("flag.explored"))
+	trace := flag.Bool("trace", false, i18n.T("flag.trace"))
+	dotFile := flag.String("dot", "", i18n.T("flag.dot"))
+	dotDepth := flag.Int("dot-depth", 0, i18n.T("flag.dotDepth"))
+	dotNodes := flag.Int("dot-nodes", 0, i18n.T("flag.dotNodes"))
+	gifFile := flag.String("gif", "", i18n.T("flag.gif"))
+	frameDelay := flag.Duration("delay", 500*time.Millisecond, i18n.T("flag.delay"))
+	gifScale := flag.Float64("scale", 1, i18n.T("flag.scale"))
+	timeout := flag.Duration("timeout", 0, i18n.T("flag.timeout"))
+	maxNodes := flag.Int("max-nodes", 0, i18n.T("flag.maxNodes"))
+	maxMemory := flag.Uint64("max-memory", 0, i18n.T("flag.maxMemory"))
+	mapDir := flag.String("maps", "", i18n.T("flag.maps", utils.MapDirEnv))
+	sessionDir := flag.String("session", "", i18n.T("flag.session", session.DirEnv))
+	historyCSV := flag.String("history-csv", "", i18n.T("flag.historyCSV"))
+	language := flag.String("lang", "", i18n.T("flag.lang", i18n.LanguageEnv))
+	validatePath := flag.String("validate", "", i18n.T("flag.validate"))
+	pathFile := flag.String("save-path", "", i18n.T("flag.savePath"))
+	bench := flag.Bool("bench", false, i18n.T("flag.bench"))
+	lenient := flag.Bool("lenient", false, i18n.T("flag.lenient"))
 
 	// Parse the flags
 	flag.Parse()
 
+	if *mapDir != "" {
+		utils.SetMapDir(*mapDir)
+	}
+	if *sessionDir != "" {
+		session.SetDir(*sessionDir)
+	}
+	if *lenient {
+		utils.SetParseMode(utils.Lenient)
+	}
+
+	settings, err := session.LoadSettings()
+	if err != nil {
+		log.Print(i18n.T("log.loadSettings", err))
+	}
+	// The flag goes before the language picked in the menu, and both before the locale
+	switch {
+	case *language != "":
+		if !i18n.SetLanguage(*language) {
+			log.Fatal(i18n.T("error.unknownLanguage", *language))
+		}
+	case settings.Language != "":
+		i18n.SetLanguage(settings.Language)
+	}
+
+	if *historyCSV != "" {
+		if err := session.ExportCSV(*historyCSV); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(i18n.T("cli.historyExported", *historyCSV))
+		return
+	}
+
+	limits := searchAlgorithms.Limits{Timeout: *timeout, MaxExpansions: *maxNodes, MaxMemory: *maxMemory << 20}
+
+	if *validatePath != "" {
+		valid, err := validatePathFile(os.Stdout, *mapFile, *validatePath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if !valid {
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *bench {
+		passed, err := runBenchmark(os.Stdout, limits)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if !passed {
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *importImage != "" {
+		options := utils.ImageImportOptions{BlockSize: *blockSize, Tolerance: *tolerance}
+		if *palette != "" {
+			options.Palette, err = utils.ParsePalette(*palette)
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+		matrix, err := utils.GetMatrixFromImage(*importImage, options)
+		if err != nil {
+			log.Fatal(err)
+		}
+		targetPath, err := utils.SaveMap(strings.TrimSuffix(filepath.Base(*importImage), filepath.Ext(*importImage))+".txt", matrix.Matrix)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(i18n.T("cli.mapSaved", targetPath))
+		return
+	}
+
 	// Check if verbose mode is enabled
 	if *cmd {
-		matrix, err := utils.GetMatrix("../battery/Prueba1.txt") // Load the matrix
+		matrix, err := utils.GetMatrix(*mapFile) // Load the matrix
+		if err != nil {
+			log.Fatal(err)
+		}
+		var logObserver searchAlgorithms.SearchObserver
+		if *trace {
+			logObserver = searchAlgorithms.LogObserver{Writer: os.Stdout}
+		}
+		searchTrace := &searchAlgorithms.SearchTrace{}
+		result, err := searchAlgorithms.RunLimited(context.Background(), *algorithm, matrix, searchAlgorithms.Observers(logObserver, searchTrace), limits)
 		if err != nil {
 			log.Fatal(err)
 		}
-		result := searchAlgorithms.StartSearch(2, matrix)
 		fmt.Println(result)
+		fmt.Println(i18n.T("cli.status", i18n.Status(result.Status.String())))
+		if result.SolutionFound {
+			// The cost each algorithm reports is checked against the path it found
+			report := validator.Check(matrix, result.PathFound)
+			fmt.Println(i18n.T("validator.checkedCost", report.Cost))
+			if !report.Valid() {
+				printReport(os.Stdout, report)
+			} else if !report.CostMatches(result.Cost) {
+				fmt.Println(i18n.T("validator.costMismatch", i18n.Algorithm(*algorithm), result.Cost, report.Cost))
+			}
+			fmt.Println(i18n.T("directions.title") + ":")
+			fmt.Print(directions.Text(directions.Generate(matrix, result.PathFound)))
+			if *pathFile != "" {
+				if err := savePath(*pathFile, result.PathFound); err != nil {
+					log.Fatal(err)
+				}
+				fmt.Println(i18n.T("validator.pathSaved", *pathFile))
+			}
+		}
+		if err := session.Record(session.NewEntry(session.SourceCLI, *mapFile, matrix.Matrix, *algorithm, result)); err != nil {
+			log.Print(i18n.T("log.recordRun", err))
+		}
+
+		renderOptions := render.Options{TileSize: *tileSize, FlatColors: *flatColors, ShowExplored: *showExplored}
+		if *pngFile != "" {
+			if err := render.WritePNGFile(*pngFile, matrix, &result, renderOptions); err != nil {
+				log.Fatal(err)
+			}
+		}
+		if *svgFile != "" {
+			if err := render.WriteSVGFile(*svgFile, matrix, &result, renderOptions); err != nil {
+				log.Fatal(err)
+			}
+		}
+		if *dotFile != "" {
+			tree := searchAlgorithms.BuildSearchTree(searchTrace)
+			if err := render.WriteDOTFile(*dotFile, tree, render.DOTOptions{MaxDepth: *dotDepth, MaxNodes: *dotNodes}); err != nil {
+				log.Fatal(err)
+			}
+		}
+		if *gifFile != "" {
+			gifOptions := render.GIFOptions{Options: renderOptions, FrameDelay: *frameDelay, Scale: *gifScale}
+			if err := render.WriteGIFFile(*gifFile, matrix, result, gifOptions); err != nil {
+				log.Fatal(err)
+			}
+		}
 	} else {
-		icon := utils.LoadIcon("./game/assets/images/cantidad-nodos.png")
+		icon, err := utils.LoadIcon("cantidad-nodos.png")
+		if err != nil {
+			log.Fatal(err)
+		}
 
 		matrixFileName := "Prueba1.txt"
 
-		var err error
-		g, err = game.NewGame(matrixFileName)
+		g, err = game.NewGame(matrixFileName, settings)
 		if err != nil {
 			log.Fatal(err)
 		}
 		//g.SetCarPath("callDummy")
-		ebiten.SetWindowSize(640, 640)
-		ebiten.SetWindowTitle("DidIA Game")
+		ebiten.SetWindowSize(640, 704)
+		if width, height := g.WindowSize(); width > 0 && height > 0 {
+			ebiten.SetWindowSize(width, height)
+		}
+		ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
+		ebiten.SetWindowTitle(i18n.T("game.windowTitle"))
 		ebiten.SetWindowIcon([]image.Image{icon})
 		if err := ebiten.RunGame(g); err != nil {
 			log.Fatal(err)
 		}
+		g.SaveSettings()
 	}
 }
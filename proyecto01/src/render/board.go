@@ -0,0 +1,196 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"os"
+
+	"github.com/Krud3/InteligenciaArtificial/src/datatypes"
+	"github.com/Krud3/InteligenciaArtificial/src/utils"
+)
+
+const defaultTileSize = 64
+
+// Options configures the headless rendering of a board
+type Options struct {
+	// Size in pixels of each cell, 64 (the sprite size) by default
+	TileSize int
+	// Draw flat colours instead of the game sprites
+	FlatColors bool
+	// Folder of the game sprites, empty for the ones embedded in the binary
+	AssetDir string
+	// Tint the cells expanded by the search
+	ShowExplored bool
+}
+
+var (
+	exploredTint = color.NRGBA{R: 60, G: 140, B: 255, A: 90}
+	pathColor    = color.RGBA{R: 230, G: 20, B: 120, A: 255}
+)
+
+func (o Options) tileSize() int {
+	if o.TileSize <= 0 {
+		return defaultTileSize
+	}
+	return o.TileSize
+}
+
+// flatColor returns the colour of a cell value, the same key used to import PNG maps
+func flatColor(value int) color.RGBA {
+	for key, paletteValue := range utils.DefaultPalette {
+		if paletteValue == value {
+			return key
+		}
+	}
+	return color.RGBA{R: 255, G: 255, B: 255, A: 255}
+}
+
+// Board draws the matrix and, if given, the explored cells and the path of the result
+func Board(scannedMatrix datatypes.ScannedMatrix, result *datatypes.SearchResult, options Options) (*image.RGBA, error) {
+	tileSize := options.tileSize()
+	rows, columns := boardSize(scannedMatrix.Matrix)
+	canvas := image.NewRGBA(image.Rect(0, 0, columns*tileSize, rows*tileSize))
+
+	var sprites *spriteSet
+	if !options.FlatColors {
+		var err error
+		sprites, err = loadSprites(options.AssetDir, tileSize)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	drawTiles(canvas, scannedMatrix.Matrix, sprites, tileSize)
+	if result != nil && options.ShowExplored {
+		drawExplored(canvas, result.Explored, tileSize)
+	}
+
+	if sprites != nil {
+		if init, found := scannedMatrix.MainCoordinates["init"]; found {
+			draw.Draw(canvas, cellRect(init, tileSize), sprites.car, image.Point{}, draw.Over)
+		}
+		if passenger, found := scannedMatrix.MainCoordinates["passenger"]; found {
+			draw.Draw(canvas, cellRect(passenger, tileSize), sprites.passenger, image.Point{}, draw.Over)
+		}
+	}
+
+	if result != nil {
+		drawRoute(canvas, Route(scannedMatrix, *result), tileSize)
+	}
+	return canvas, nil
+}
+
+// drawTiles draws the background of every cell, with sprites or flat colours if sprites is nil
+func drawTiles(canvas draw.Image, matrix datatypes.Matrix, sprites *spriteSet, tileSize int) {
+	for row, values := range matrix {
+		for column, value := range values {
+			cell := cellRect(datatypes.BoardCoordinate{X: row, Y: column}, tileSize)
+			if sprites == nil {
+				draw.Draw(canvas, cell, image.NewUniform(flatColor(value)), image.Point{}, draw.Src)
+				continue
+			}
+			draw.Draw(canvas, cell, sprites.tile(value), image.Point{}, draw.Src)
+		}
+	}
+}
+
+func drawExplored(canvas draw.Image, explored []datatypes.BoardCoordinate, tileSize int) {
+	for _, coordinate := range explored {
+		draw.Draw(canvas, cellRect(coordinate, tileSize), image.NewUniform(exploredTint), image.Point{}, draw.Over)
+	}
+}
+
+func drawRoute(canvas draw.Image, route []datatypes.BoardCoordinate, tileSize int) {
+	for i := 1; i < len(route); i++ {
+		drawArrow(canvas, route[i-1], route[i], tileSize, pathColor)
+	}
+}
+
+// WritePNG renders the board as a PNG image
+func WritePNG(w io.Writer, scannedMatrix datatypes.ScannedMatrix, result *datatypes.SearchResult, options Options) error {
+	canvas, err := Board(scannedMatrix, result, options)
+	if err != nil {
+		return err
+	}
+	return png.Encode(w, canvas)
+}
+
+// WritePNGFile renders the board into a PNG file
+func WritePNGFile(path string, scannedMatrix datatypes.ScannedMatrix, result *datatypes.SearchResult, options Options) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return WritePNG(file, scannedMatrix, result, options)
+}
+
+// Route returns the path of the result starting at the initial position of the map
+func Route(scannedMatrix datatypes.ScannedMatrix, result datatypes.SearchResult) []datatypes.BoardCoordinate {
+	route := result.PathFound
+	init, found := scannedMatrix.MainCoordinates["init"]
+	if found && len(route) > 0 && route[0] != init {
+		route = append([]datatypes.BoardCoordinate{init}, route...)
+	}
+	return route
+}
+
+func boardSize(matrix datatypes.Matrix) (int, int) {
+	columns := 0
+	for _, row := range matrix {
+		columns = max(columns, len(row))
+	}
+	return len(matrix), columns
+}
+
+func cellRect(coordinate datatypes.BoardCoordinate, tileSize int) image.Rectangle {
+	return image.Rect(coordinate.Y*tileSize, coordinate.X*tileSize, (coordinate.Y+1)*tileSize, (coordinate.X+1)*tileSize)
+}
+
+// drawArrow draws a straight arrow between the centres of two cells
+func drawArrow(canvas draw.Image, from, to datatypes.BoardCoordinate, tileSize int, c color.Color) {
+	fromCell, toCell := cellRect(from, tileSize), cellRect(to, tileSize)
+	x0, y0 := (fromCell.Min.X+fromCell.Max.X)/2, (fromCell.Min.Y+fromCell.Max.Y)/2
+	x1, y1 := (toCell.Min.X+toCell.Max.X)/2, (toCell.Min.Y+toCell.Max.Y)/2
+	dx, dy := sign(x1-x0), sign(y1-y0)
+	if dx == 0 && dy == 0 {
+		return
+	}
+
+	width := max(tileSize/16, 1)
+	head := max(tileSize/5, 3)
+	tipX, tipY := x1-dx*tileSize/4, y1-dy*tileSize/4
+	baseX, baseY := tipX-dx*head, tipY-dy*head
+
+	// Shaft
+	shaft := image.Rect(min(x0, baseX)-width*abs(dy), min(y0, baseY)-width*abs(dx), max(x0, baseX)+width*abs(dy)+1, max(y0, baseY)+width*abs(dx)+1)
+	draw.Draw(canvas, shaft, image.NewUniform(c), image.Point{}, draw.Over)
+
+	// Head: a triangle that narrows from the base to the tip
+	for step := 0; step <= head; step++ {
+		halfWidth := (head - step) * 2 / 3
+		cx, cy := baseX+dx*step, baseY+dy*step
+		line := image.Rect(cx-halfWidth*abs(dy), cy-halfWidth*abs(dx), cx+halfWidth*abs(dy)+1, cy+halfWidth*abs(dx)+1)
+		draw.Draw(canvas, line, image.NewUniform(c), image.Point{}, draw.Over)
+	}
+}
+
+func sign(value int) int {
+	switch {
+	case value > 0:
+		return 1
+	case value < 0:
+		return -1
+	}
+	return 0
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
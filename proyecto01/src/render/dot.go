@@ -0,0 +1,86 @@
+package render
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Krud3/InteligenciaArtificial/src/searchAlgorithms"
+)
+
+// DOTOptions limits the size of the exported tree, 0 means no limit.
+// The solution branch is always exported.
+type DOTOptions struct {
+	MaxDepth int
+	MaxNodes int
+}
+
+// WriteDOT exports the search tree as a Graphviz digraph
+func WriteDOT(w io.Writer, tree *searchAlgorithms.SearchTree, options DOTOptions) error {
+	included := make([]bool, len(tree.Nodes))
+	count := 0
+	for _, node := range tree.Nodes {
+		withinLimits := (options.MaxDepth <= 0 || node.TreeDepth <= options.MaxDepth) &&
+			(options.MaxNodes <= 0 || count < options.MaxNodes)
+		// Children of a dropped node would be left floating
+		parentIncluded := node.Parent < 0 || included[node.Parent]
+		if node.OnSolution || (withinLimits && parentIncluded) {
+			included[node.ID] = true
+			count++
+		}
+	}
+
+	out := bufio.NewWriter(w)
+	fmt.Fprintln(out, "digraph SearchTree {")
+	fmt.Fprintln(out, `  node [shape=box, fontname="monospace", fontsize=10];`)
+	for _, node := range tree.Nodes {
+		if !included[node.ID] {
+			continue
+		}
+		label := fmt.Sprintf("(%d,%d)", node.Node.Position.X, node.Node.Position.Y)
+		if node.Node.HasPickedUpPassenger {
+			label += " P"
+		}
+		label += fmt.Sprintf(`\ng=%.0f h=%.0f f=%.0f`, node.Node.G, node.Node.H, node.Node.F)
+		if node.ExpansionOrder > 0 {
+			label += fmt.Sprintf(`\n#%d`, node.ExpansionOrder)
+		}
+
+		attributes := ""
+		switch {
+		case node.OnSolution:
+			attributes = `, color=red, penwidth=2, style=filled, fillcolor="#ffe0e0"`
+		case node.Pruned:
+			attributes = ", color=gray, fontcolor=gray, style=dashed"
+		case node.ExpansionOrder == 0:
+			// Still in the frontier when the search ended
+			attributes = ", color=blue"
+		}
+		fmt.Fprintf(out, "  n%d [label=\"%s\"%s];\n", node.ID, label, attributes)
+	}
+	for _, node := range tree.Nodes {
+		if !included[node.ID] || node.Parent < 0 || !included[node.Parent] {
+			continue
+		}
+		attributes := ""
+		if node.OnSolution {
+			attributes = " [color=red, penwidth=2]"
+		} else if node.Pruned {
+			attributes = " [color=gray, style=dashed]"
+		}
+		fmt.Fprintf(out, "  n%d -> n%d%s;\n", node.Parent, node.ID, attributes)
+	}
+	fmt.Fprintln(out, "}")
+	return out.Flush()
+}
+
+// WriteDOTFile exports the search tree into a Graphviz file
+func WriteDOTFile(path string, tree *searchAlgorithms.SearchTree, options DOTOptions) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return WriteDOT(file, tree, options)
+}
@@ -0,0 +1,147 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"io"
+	"math"
+	"os"
+	"time"
+
+	"github.com/Krud3/InteligenciaArtificial/src/datatypes"
+)
+
+const defaultFrameDelay = 500 * time.Millisecond
+
+// GIFOptions configures the animation of a solution run
+type GIFOptions struct {
+	Options
+	// Time each frame is shown, half a second (the game's 30 frames) by default
+	FrameDelay time.Duration
+	// Multiplier of the tile size, 1 by default
+	Scale float64
+	// Draw the path arrows under the car
+	ShowPath bool
+}
+
+// Animation returns the frames of the car following the path of the result,
+// picking up the passenger on the way like the game does
+func Animation(scannedMatrix datatypes.ScannedMatrix, result datatypes.SearchResult, options GIFOptions) (*gif.GIF, error) {
+	tileSize := options.tileSize()
+	if options.Scale > 0 {
+		tileSize = max(int(math.Round(float64(tileSize)*options.Scale)), 1)
+	}
+	delay := options.FrameDelay
+	if delay <= 0 {
+		delay = defaultFrameDelay
+	}
+
+	// The car and the passenger are drawn even when the tiles are flat colours
+	sprites, err := loadSprites(options.AssetDir, tileSize)
+	if err != nil {
+		return nil, err
+	}
+	tiles := sprites
+	if options.FlatColors {
+		tiles = nil
+	}
+
+	rows, columns := boardSize(scannedMatrix.Matrix)
+	background := image.NewRGBA(image.Rect(0, 0, columns*tileSize, rows*tileSize))
+	drawTiles(background, scannedMatrix.Matrix, tiles, tileSize)
+	if options.ShowExplored {
+		drawExplored(background, result.Explored, tileSize)
+	}
+	route := Route(scannedMatrix, result)
+	if options.ShowPath {
+		drawRoute(background, route, tileSize)
+	}
+
+	init, hasInit := scannedMatrix.MainCoordinates["init"]
+	if len(route) == 0 && hasInit {
+		route = []datatypes.BoardCoordinate{init}
+	}
+	passenger, hasPassenger := scannedMatrix.MainCoordinates["passenger"]
+
+	framePalette := buildPalette(background, sprites.car, sprites.carWithPassenger, sprites.passenger)
+	animation := &gif.GIF{}
+	pickedUp := false
+	for i, position := range route {
+		// Same check as Game.UpdateGame: the passenger is picked up once the car is on its cell
+		if hasPassenger && position == passenger {
+			pickedUp = true
+		}
+
+		frame := image.NewRGBA(background.Bounds())
+		draw.Draw(frame, frame.Bounds(), background, image.Point{}, draw.Src)
+		if hasPassenger && !pickedUp {
+			draw.Draw(frame, cellRect(passenger, tileSize), sprites.passenger, image.Point{}, draw.Over)
+		}
+		carImage := sprites.car
+		if pickedUp {
+			carImage = sprites.carWithPassenger
+		}
+		draw.Draw(frame, cellRect(position, tileSize), carImage, image.Point{}, draw.Over)
+
+		paletted := image.NewPaletted(frame.Bounds(), framePalette)
+		draw.Draw(paletted, paletted.Bounds(), frame, image.Point{}, draw.Src)
+
+		frameDelay := int(delay / (10 * time.Millisecond))
+		if i == len(route)-1 {
+			// Hold the last frame before the animation loops
+			frameDelay *= 4
+		}
+		animation.Image = append(animation.Image, paletted)
+		animation.Delay = append(animation.Delay, max(frameDelay, 1))
+	}
+	return animation, nil
+}
+
+// WriteGIF renders the solution run as an animated GIF
+func WriteGIF(w io.Writer, scannedMatrix datatypes.ScannedMatrix, result datatypes.SearchResult, options GIFOptions) error {
+	animation, err := Animation(scannedMatrix, result, options)
+	if err != nil {
+		return err
+	}
+	return gif.EncodeAll(w, animation)
+}
+
+// WriteGIFFile renders the solution run into an animated GIF file
+func WriteGIFFile(path string, scannedMatrix datatypes.ScannedMatrix, result datatypes.SearchResult, options GIFOptions) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return WriteGIF(file, scannedMatrix, result, options)
+}
+
+// buildPalette uses the exact colours of the images when they fit in a GIF,
+// otherwise it falls back to the Plan 9 palette
+func buildPalette(images ...image.Image) color.Palette {
+	seen := make(map[color.RGBA]bool)
+	var colors color.Palette
+	for _, img := range images {
+		bounds := img.Bounds()
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			for x := bounds.Min.X; x < bounds.Max.X; x++ {
+				c := color.RGBAModel.Convert(img.At(x, y)).(color.RGBA)
+				if c.A != 255 || seen[c] {
+					continue
+				}
+				seen[c] = true
+				colors = append(colors, c)
+				if len(colors) > 256 {
+					return palette.Plan9
+				}
+			}
+		}
+	}
+	if len(colors) == 0 {
+		return palette.Plan9
+	}
+	return colors
+}
@@ -0,0 +1,91 @@
+package render
+
+import (
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+
+	"github.com/Krud3/InteligenciaArtificial/src/game/assets"
+	"github.com/Krud3/InteligenciaArtificial/src/i18n"
+)
+
+// spriteSet holds the game sprites already scaled to the tile size
+type spriteSet struct {
+	road, wall, mediumTraffic, heavyTraffic, goal image.Image
+	car, carWithPassenger, passenger              image.Image
+}
+
+func loadSprites(assetDir string, tileSize int) (*spriteSet, error) {
+	load := func(name string) (image.Image, error) {
+		// Without a directory the sprites embedded in the binary are used
+		if assetDir == "" {
+			img, err := assets.Decode(name)
+			if err != nil {
+				return nil, err
+			}
+			return scale(img, tileSize), nil
+		}
+		file, err := os.Open(filepath.Join(assetDir, name))
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		img, err := png.Decode(file)
+		if err != nil {
+			return nil, i18n.Errorf("error.decode", name, err)
+		}
+		return scale(img, tileSize), nil
+	}
+
+	sprites := &spriteSet{}
+	files := map[*image.Image]string{
+		&sprites.road:             "calle-uldr.png",
+		&sprites.wall:             "muro-1.png",
+		&sprites.mediumTraffic:    "trafico-medio.png",
+		&sprites.heavyTraffic:     "trafico-pesado.png",
+		&sprites.goal:             "destino.png",
+		&sprites.car:              "moto-1-narvaez.png",
+		&sprites.carWithPassenger: "moto-1-narvaez-girl.png",
+		&sprites.passenger:        "girl.png",
+	}
+	for target, name := range files {
+		img, err := load(name)
+		if err != nil {
+			return nil, err
+		}
+		*target = img
+	}
+	return sprites, nil
+}
+
+// tile returns the background sprite of a cell value, like game.Scene does
+func (s *spriteSet) tile(value int) image.Image {
+	switch value {
+	case 1:
+		return s.wall
+	case 3:
+		return s.mediumTraffic
+	case 4:
+		return s.heavyTraffic
+	case 6:
+		return s.goal
+	default:
+		return s.road
+	}
+}
+
+// scale resizes an image with nearest neighbour, which keeps the pixel art sharp
+func scale(img image.Image, size int) image.Image {
+	bounds := img.Bounds()
+	if bounds.Dx() == size && bounds.Dy() == size && bounds.Min == (image.Point{}) {
+		return img
+	}
+	scaled := image.NewRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			scaled.Set(x, y, img.At(bounds.Min.X+x*bounds.Dx()/size, bounds.Min.Y+y*bounds.Dy()/size))
+		}
+	}
+	return scaled
+}
@@ -0,0 +1,138 @@
+package render
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"os"
+
+	"github.com/Krud3/InteligenciaArtificial/src/datatypes"
+)
+
+// WriteSVG renders the board as an SVG document
+func WriteSVG(w io.Writer, scannedMatrix datatypes.ScannedMatrix, result *datatypes.SearchResult, options Options) error {
+	tileSize := options.tileSize()
+	rows, columns := boardSize(scannedMatrix.Matrix)
+
+	var sprites *spriteSet
+	if !options.FlatColors {
+		var err error
+		sprites, err = loadSprites(options.AssetDir, tileSize)
+		if err != nil {
+			return err
+		}
+	}
+
+	out := bufio.NewWriter(w)
+	fmt.Fprintf(out, `<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		columns*tileSize, rows*tileSize, columns*tileSize, rows*tileSize)
+
+	// Arrow head shared by every step of the path
+	fmt.Fprintf(out, "<defs>\n")
+	fmt.Fprintf(out, `<marker id="arrow" viewBox="0 0 10 10" refX="8" refY="5" markerWidth="4" markerHeight="4" orient="auto"><path d="M0,0 L10,5 L0,10 z" fill="%s"/></marker>`+"\n", hexColor(pathColor))
+	if sprites != nil {
+		named := []struct {
+			id  string
+			img image.Image
+		}{
+			{"road", sprites.road}, {"wall", sprites.wall}, {"medium", sprites.mediumTraffic},
+			{"heavy", sprites.heavyTraffic}, {"goal", sprites.goal}, {"car", sprites.car}, {"passenger", sprites.passenger},
+		}
+		for _, sprite := range named {
+			encoded, err := encodePNG(sprite.img)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(out, `<image id="%s" width="%d" height="%d" xlink:href="data:image/png;base64,%s"/>`+"\n", sprite.id, tileSize, tileSize, encoded)
+		}
+	}
+	fmt.Fprintf(out, "</defs>\n")
+
+	for row, values := range scannedMatrix.Matrix {
+		for column, value := range values {
+			x, y := column*tileSize, row*tileSize
+			if sprites == nil {
+				fmt.Fprintf(out, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n", x, y, tileSize, tileSize, hexColor(flatColor(value)))
+				continue
+			}
+			fmt.Fprintf(out, `<use xlink:href="#%s" x="%d" y="%d"/>`+"\n", spriteID(value), x, y)
+		}
+	}
+
+	if result != nil && options.ShowExplored {
+		for _, coordinate := range result.Explored {
+			fmt.Fprintf(out, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s" fill-opacity="%.2f"/>`+"\n",
+				coordinate.Y*tileSize, coordinate.X*tileSize, tileSize, tileSize, hexColor(exploredTint), float64(exploredTint.A)/255)
+		}
+	}
+
+	if sprites != nil {
+		if init, found := scannedMatrix.MainCoordinates["init"]; found {
+			fmt.Fprintf(out, `<use xlink:href="#car" x="%d" y="%d"/>`+"\n", init.Y*tileSize, init.X*tileSize)
+		}
+		if passenger, found := scannedMatrix.MainCoordinates["passenger"]; found {
+			fmt.Fprintf(out, `<use xlink:href="#passenger" x="%d" y="%d"/>`+"\n", passenger.Y*tileSize, passenger.X*tileSize)
+		}
+	}
+
+	if result != nil {
+		route := Route(scannedMatrix, *result)
+		strokeWidth := max(tileSize/8, 1)
+		for i := 1; i < len(route); i++ {
+			from, to := route[i-1], route[i]
+			x0, y0 := from.Y*tileSize+tileSize/2, from.X*tileSize+tileSize/2
+			x1, y1 := to.Y*tileSize+tileSize/2, to.X*tileSize+tileSize/2
+			// Stop before the centre so consecutive arrows don't overlap
+			x1 -= sign(x1-x0) * tileSize / 4
+			y1 -= sign(y1-y0) * tileSize / 4
+			fmt.Fprintf(out, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s" stroke-width="%d" marker-end="url(#arrow)"/>`+"\n",
+				x0, y0, x1, y1, hexColor(pathColor), strokeWidth)
+		}
+	}
+
+	fmt.Fprintf(out, "</svg>\n")
+	return out.Flush()
+}
+
+// WriteSVGFile renders the board into an SVG file
+func WriteSVGFile(path string, scannedMatrix datatypes.ScannedMatrix, result *datatypes.SearchResult, options Options) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return WriteSVG(file, scannedMatrix, result, options)
+}
+
+func spriteID(value int) string {
+	switch value {
+	case 1:
+		return "wall"
+	case 3:
+		return "medium"
+	case 4:
+		return "heavy"
+	case 6:
+		return "goal"
+	default:
+		return "road"
+	}
+}
+
+func hexColor(c color.Color) string {
+	rgb := color.NRGBAModel.Convert(c).(color.NRGBA)
+	return fmt.Sprintf("#%02x%02x%02x", rgb.R, rgb.G, rgb.B)
+}
+
+func encodePNG(img image.Image) (string, error) {
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, img); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buffer.Bytes()), nil
+}
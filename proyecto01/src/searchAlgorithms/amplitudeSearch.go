@@ -16,15 +16,27 @@ func (b *AmplitudeSearch) LookForGoal(env *Environment) SearchResult {
 	visited := make(map[Position]bool)
 	visited[env.InitPosition] = true
 	parent := make(map[Position]Position)
+	depth := make(map[Position]int)
+	ids := map[Position]int{env.InitPosition: env.newID()}
+	notify := env.notify()
 	var solutionFound bool
 	var expandedNodes int
 	var cost float32
+	var explored []Position
 
-	for len(queue) > 0 {
+	for len(queue) > 0 && !notify.stopped() {
 		current := queue[0]
 		queue = queue[1:]
 		expandedNodes++
+		explored = append(explored, current)
+		parentID := 0
+		if previous, hasParent := parent[current]; hasParent {
+			parentID = ids[previous]
+		}
+		currentNode := positionNode(ids[current], current, parentID, parent[current], depth[current])
+		notify.expand(currentNode)
 
+		notify.goalTest(currentNode, current == env.GoalPosition)
 		if current == env.GoalPosition {
 			solutionFound = true
 			break
@@ -32,10 +44,16 @@ func (b *AmplitudeSearch) LookForGoal(env *Environment) SearchResult {
 
 		neighbors := getNeighbors(current, env)
 		for _, neighbor := range neighbors {
+			neighborNode := positionNode(env.newID(), neighbor, ids[current], current, depth[current]+1)
 			if !visited[neighbor] {
 				visited[neighbor] = true
+				ids[neighbor] = neighborNode.ID
 				parent[neighbor] = current
+				depth[neighbor] = depth[current] + 1
 				queue = append(queue, neighbor)
+				notify.generate(neighborNode)
+			} else {
+				notify.prune(neighborNode)
 			}
 		}
 	}
@@ -49,19 +67,22 @@ func (b *AmplitudeSearch) LookForGoal(env *Environment) SearchResult {
 		}
 		path = append([]Position{env.InitPosition}, path...)
 		b.SolutionPath = path
-		cost = float32(len(path))
+		cost = env.pathCost(path)
 	}
 
 	timeExe := time.Since(startTime)
 
-	return SearchResult{
+	result := SearchResult{
 		SolutionFound: solutionFound,
 		ExpandedNodes: expandedNodes,
 		TreeDepth:     len(path),
 		Cost:          cost,
 		TimeExecuted:  timeExe,
 		Path:          path,
+		Explored:      explored,
 	}
+	notify.solution(result.toDatatypes())
+	return result
 }
 
 func getNeighbors(pos Position, env *Environment) []Position {
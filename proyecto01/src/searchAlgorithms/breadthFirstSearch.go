@@ -1,7 +1,6 @@
 package searchAlgorithms
 
 import (
-	"fmt"
 	"math"
 	"time"
 
@@ -18,13 +17,17 @@ func (a *BreadthFirstSearch) LookForGoal(e *enviroment) datatypes.SearchResult {
 	initialPosition := e.agent.position
 	queue := datatypes.Queue[datatypes.AgentStep]{}
 	queue.Enqueue(initialPosition)
+	// Cells already queued in the current phase, without it a map with no
+	// solution fills the queue forever
+	visited := map[datatypes.BoardCoordinate]bool{initialPosition.CurrentPosition: true}
 
 	start := time.Now()
 
-	pathToPassenger := []datatypes.BoardCoordinate{} // Path from initial position to the passenger
-	pathToGoal := []datatypes.BoardCoordinate{}      // Path from passenger to the goal
+	// Path from initial position to the passenger, just the start if the passenger waits there
+	pathToPassenger := []datatypes.BoardCoordinate{initialPosition.CurrentPosition}
+	pathToGoal := []datatypes.BoardCoordinate{} // Path from passenger to the goal
 
-	for !queue.IsEmpty() {
+	for !queue.IsEmpty() && !e.notify.stopped() {
 		currentStep, empty := queue.Dequeue()
 		if empty {
 			return datatypes.SearchResult{
@@ -39,6 +42,9 @@ func (a *BreadthFirstSearch) LookForGoal(e *enviroment) datatypes.SearchResult {
 
 		parentNodes = append(parentNodes, currentStep)
 
+		reachedGoal := e.agent.passenger && e.board[currentStep.CurrentPosition.X][currentStep.CurrentPosition.Y] == 6
+		e.notify.goalTest(stepNode(currentStep, e.agent.passenger), reachedGoal)
+
 		// Phase 1: Find the passenger
 		if !e.agent.passenger && e.board[currentStep.CurrentPosition.X][currentStep.CurrentPosition.Y] == 5 {
 			e.passengerPosition = datatypes.BoardCoordinate{
@@ -53,40 +59,39 @@ func (a *BreadthFirstSearch) LookForGoal(e *enviroment) datatypes.SearchResult {
 			// Clear the queue and start BFS again from the passenger's position
 			queue.Clear()
 			parentNodes = nil // Clear parent nodes for the next phase
-			queue.Enqueue(
-				datatypes.AgentStep{
-					PreviousPosition: datatypes.BoardCoordinate{
-						X: math.MaxInt,
-						Y: math.MaxInt,
-					},
-					CurrentPosition: currentStep.CurrentPosition,
-					Depth:           currentStep.Depth + 1,
-					Action:          math.MaxInt,
+			visited = map[datatypes.BoardCoordinate]bool{currentStep.CurrentPosition: true}
+			passengerStep := datatypes.AgentStep{
+				PreviousPosition: datatypes.BoardCoordinate{
+					X: math.MaxInt,
+					Y: math.MaxInt,
 				},
-			) // Start from the passenger's position
+				CurrentPosition: currentStep.CurrentPosition,
+				Depth:           currentStep.Depth + 1,
+				Action:          math.MaxInt,
+				// For the observers the new phase hangs from the node where the passenger was found
+				ID:       e.newID(),
+				ParentID: currentStep.ID,
+			}
+			queue.Enqueue(passengerStep) // Start from the passenger's position
+			e.notify.generate(stepNode(passengerStep, true))
 			continue
 		}
 
 		// Phase 2: Search for the goal (once the passenger is found)
-		if e.agent.passenger && e.board[currentStep.CurrentPosition.X][currentStep.CurrentPosition.Y] == 6 {
+		if reachedGoal {
 			end := time.Now()
 			// Reconstruct path from the passenger to the goal
 			pathToGoal = reconstructPath(parentNodes, currentStep)
 
 			// Combine the two paths: initial -> passenger + passenger -> goal
 			combinedPath := append(pathToPassenger[1:], pathToGoal[1:]...)
-			var cost float32
-			for step := range combinedPath {
-				fmt.Println(getCellCost(e.board[combinedPath[step].X][combinedPath[step].Y]))
-				cost += float32(getCellCost(e.board[combinedPath[step].X][combinedPath[step].Y]))
-			}
 
 			return datatypes.SearchResult{
 				PathFound:     combinedPath,
 				SolutionFound: true,
 				ExpandenNodes: expandenNodes,
 				TreeDepth:     len(combinedPath),
-				Cost:          float32(cost), // Example cost, you can modify it as needed
+				Cost:          PathCost(e.board, initialPosition.CurrentPosition, combinedPath),
 				TimeExe:       end.Sub(start),
 			}
 		}
@@ -95,20 +100,32 @@ func (a *BreadthFirstSearch) LookForGoal(e *enviroment) datatypes.SearchResult {
 		e.agent.position = currentStep
 		agentPerception := Percept(e.agent, e.board)
 		expandenNodes++
+		e.explored = append(e.explored, currentStep.CurrentPosition)
+		e.notify.expand(stepNode(currentStep, e.agent.passenger))
 		for _, perception := range agentPerception {
 			cost := currentStep.Cost + int(getCellCost(e.board[perception.Coordinate.X][perception.Coordinate.Y]))
-			if (perception.Coordinate.X != currentStep.PreviousPosition.X) || (perception.Coordinate.Y != currentStep.PreviousPosition.Y) {
-				queue.Enqueue(
-					datatypes.AgentStep{
-						Action:           perception.Direction,
-						Depth:            currentStep.Depth + 1,
-						CurrentPosition:  perception.Coordinate,
-						PreviousPosition: currentStep.CurrentPosition,
-						Cost:             int(cost),
-					},
-				)
+			nextStep := datatypes.AgentStep{
+				Action:           perception.Direction,
+				Depth:            currentStep.Depth + 1,
+				CurrentPosition:  perception.Coordinate,
+				PreviousPosition: currentStep.CurrentPosition,
+				Cost:             int(cost),
+				ID:               e.newID(),
+				ParentID:         currentStep.ID,
+			}
+			if !visited[perception.Coordinate] {
+				visited[perception.Coordinate] = true
+				queue.Enqueue(nextStep)
+				e.notify.generate(stepNode(nextStep, e.agent.passenger))
+			} else {
+				e.notify.prune(stepNode(nextStep, e.agent.passenger))
 			}
 		}
 	}
-	return datatypes.SearchResult{}
+	return datatypes.SearchResult{
+		PathFound:     []datatypes.BoardCoordinate{},
+		SolutionFound: false,
+		ExpandenNodes: expandenNodes,
+		TimeExe:       time.Since(start),
+	}
 }
@@ -0,0 +1,213 @@
+// Package conformance is a test kit every search algorithm has to pass: it
+// solves the battery and some small edge case maps, checks the paths with the
+// validator, and compares the cost against an exact search for the
+// algorithms that claim to find the cheapest route
+package conformance
+
+import (
+	"context"
+	"io/fs"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Krud3/InteligenciaArtificial/battery"
+	"github.com/Krud3/InteligenciaArtificial/src/datatypes"
+	"github.com/Krud3/InteligenciaArtificial/src/searchAlgorithms"
+	"github.com/Krud3/InteligenciaArtificial/src/utils"
+	"github.com/Krud3/InteligenciaArtificial/src/validator"
+)
+
+// Search is an algorithm under test. It has to stop when ctx is done
+type Search func(ctx context.Context, matrix datatypes.ScannedMatrix) (datatypes.SearchResult, error)
+
+// Named is the Search of one of the algorithms of searchAlgorithms
+func Named(name string) Search {
+	return func(ctx context.Context, matrix datatypes.ScannedMatrix) (datatypes.SearchResult, error) {
+		return searchAlgorithms.RunLimited(ctx, name, matrix, nil, searchAlgorithms.Limits{})
+	}
+}
+
+type Options struct {
+	// The algorithm claims to always find the cheapest route
+	Optimal bool
+	// Time each search has to finish, a minute if zero
+	Timeout time.Duration
+}
+
+// Case is a map the suite solves
+type Case struct {
+	Name   string
+	Matrix datatypes.ScannedMatrix
+}
+
+// EdgeMaps are the small maps that cover the edge cases, written like the battery
+var EdgeMaps = []struct {
+	Name     string
+	Map      string
+	Solvable bool
+}{
+	{"PassengerNextToStart", "2 5 0 6", true},
+	{"GoalNextToPassenger", "2 0 0\n1 1 0\n6 5 0", true},
+	// The route goes over the goal to pick up the passenger and comes back
+	{"GoalBeforePassenger", "2 6 0 5", true},
+	{"PassengerBehindStart", "5 2 0 0 6", true},
+	{"CheapDetour", "2 4 4 6\n0 1 1 0\n0 0 5 0", true},
+	{"HeavyTrafficOnly", "2 4 4\n1 1 4\n6 4 5", true},
+	{"OneColumn", "2\n0\n5\n3\n6", true},
+	{"WalledPassenger", "2 0 1 5\n0 0 1 1\n0 0 0 6", false},
+	{"WalledGoal", "2 0 5 1\n0 0 0 1\n1 1 1 6", false},
+	{"WalledStart", "2 1 5\n1 0 0\n0 0 6", false},
+}
+
+// BatteryCases are the maps shipped with the game
+func BatteryCases() ([]Case, error) {
+	names, err := fs.Glob(battery.Maps, "*.txt")
+	if err != nil {
+		return nil, err
+	}
+	var cases []Case
+	for _, name := range names {
+		file, err := battery.Maps.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		matrix, err := utils.ReadMatrix(file)
+		file.Close()
+		if err != nil {
+			return nil, err
+		}
+		cases = append(cases, Case{Name: name, Matrix: matrix})
+	}
+	return cases, nil
+}
+
+// ParseCase reads a map written like the battery
+func ParseCase(name, text string) (Case, error) {
+	matrix, err := utils.ReadMatrix(strings.NewReader(text))
+	return Case{Name: name, Matrix: matrix}, err
+}
+
+// StartOnPassenger is a map where the passenger waits at the start, the map
+// files can't say it so the passenger has no cell of its own
+func StartOnPassenger() Case {
+	matrix := datatypes.Matrix{{2, 0, 3, 6}}
+	start := datatypes.BoardCoordinate{X: 0, Y: 0}
+	return Case{
+		Name:   "StartOnPassenger",
+		Matrix: datatypes.ScannedMatrix{Matrix: matrix, MainCoordinates: map[string]datatypes.BoardCoordinate{"init": start, "passenger": start, "goal": {X: 0, Y: 3}}},
+	}
+}
+
+// Test runs the whole suite against a search, one subtest per group
+func Test(t *testing.T, search Search, options Options) {
+	if options.Timeout == 0 {
+		options.Timeout = time.Minute
+	}
+	batteryCases, err := BatteryCases()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var solvable, unsolvable []Case
+	for _, c := range EdgeMaps {
+		parsed, err := ParseCase(c.Name, c.Map)
+		if err != nil {
+			t.Fatalf("%s: %v", c.Name, err)
+		}
+		if c.Solvable {
+			solvable = append(solvable, parsed)
+		} else {
+			unsolvable = append(unsolvable, parsed)
+		}
+	}
+	solvable = append(append(solvable, batteryCases...), StartOnPassenger())
+
+	t.Run("Solvable", func(t *testing.T) {
+		for _, c := range solvable {
+			t.Run(c.Name, func(t *testing.T) { TestSolvable(t, search, c, options) })
+		}
+	})
+	t.Run("Unsolvable", func(t *testing.T) {
+		for _, c := range unsolvable {
+			t.Run(c.Name, func(t *testing.T) { TestUnsolvable(t, search, c, options) })
+		}
+	})
+	t.Run("Deterministic", func(t *testing.T) {
+		for _, c := range append(solvable, unsolvable...) {
+			t.Run(c.Name, func(t *testing.T) { TestDeterministic(t, search, c, options) })
+		}
+	})
+}
+
+func run(t *testing.T, search Search, c Case, options Options) (datatypes.SearchResult, error) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), options.Timeout)
+	defer cancel()
+	result, err := search(ctx, c.Matrix)
+	if ctx.Err() != nil {
+		t.Fatalf("the search didn't finish in %v", options.Timeout)
+	}
+	return result, err
+}
+
+// TestSolvable checks the search finds a valid route, with the cost of the
+// route, and the cheapest one if the algorithm claims it
+func TestSolvable(t *testing.T, search Search, c Case, options Options) {
+	result, err := run(t, search, c, options)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.SolutionFound {
+		t.Fatalf("no solution found, status %v", result.Status)
+	}
+	if result.Status != datatypes.Solved {
+		t.Errorf("status is %v for a solution", result.Status)
+	}
+
+	report := validator.Check(c.Matrix, result.PathFound)
+	if !report.Valid() {
+		t.Fatalf("invalid path %v: %s", result.PathFound, strings.Join(report.Problems, "; "))
+	}
+	if !report.CostMatches(result.Cost) {
+		t.Errorf("reported cost %v, the path costs %v", result.Cost, report.Cost)
+	}
+	if options.Optimal {
+		best, ok := OptimalCost(c.Matrix)
+		if !ok {
+			t.Fatal("the exact search found no route")
+		}
+		if report.Cost != best {
+			t.Errorf("cost %v, the cheapest route costs %v", report.Cost, best)
+		}
+	}
+}
+
+// TestUnsolvable checks the search ends without a solution or with an error
+func TestUnsolvable(t *testing.T, search Search, c Case, options Options) {
+	result, err := run(t, search, c, options)
+	if err != nil {
+		return
+	}
+	if result.SolutionFound {
+		t.Fatalf("solution %v found for a map without one", result.PathFound)
+	}
+	if result.Status != datatypes.NoSolution {
+		t.Errorf("status is %v, expected %v", result.Status, datatypes.NoSolution)
+	}
+}
+
+// TestDeterministic checks two searches over the same map give the same result
+func TestDeterministic(t *testing.T, search Search, c Case, options Options) {
+	first, firstErr := run(t, search, c, options)
+	second, secondErr := run(t, search, c, options)
+	if (firstErr == nil) != (secondErr == nil) {
+		t.Fatalf("errors differ: %v and %v", firstErr, secondErr)
+	}
+	if first.SolutionFound != second.SolutionFound || first.Cost != second.Cost || first.ExpandenNodes != second.ExpandenNodes || first.TreeDepth != second.TreeDepth {
+		t.Errorf("results differ: cost %v and %v, %v and %v nodes expanded", first.Cost, second.Cost, first.ExpandenNodes, second.ExpandenNodes)
+	}
+	if !reflect.DeepEqual(first.PathFound, second.PathFound) {
+		t.Errorf("paths differ: %v and %v", first.PathFound, second.PathFound)
+	}
+}
@@ -0,0 +1,89 @@
+package conformance
+
+import (
+	"container/heap"
+
+	"github.com/Krud3/InteligenciaArtificial/src/datatypes"
+	"github.com/Krud3/InteligenciaArtificial/src/searchAlgorithms"
+)
+
+// state of the exact search: where the car is and if it carries the passenger
+type state struct {
+	cell     datatypes.BoardCoordinate
+	pickedUp bool
+}
+
+type entry struct {
+	state state
+	cost  float32
+}
+
+type queue []entry
+
+func (q queue) Len() int            { return len(q) }
+func (q queue) Less(i, j int) bool  { return q[i].cost < q[j].cost }
+func (q queue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *queue) Push(x interface{}) { *q = append(*q, x.(entry)) }
+func (q *queue) Pop() interface{} {
+	old := *q
+	last := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return last
+}
+
+var moves = []datatypes.BoardCoordinate{{X: -1}, {Y: 1}, {X: 1}, {Y: -1}}
+
+// OptimalCost is the cost of the cheapest route that picks up the passenger
+// and then reaches the goal, found with Dijkstra over the cells and whether
+// the passenger is in the car. It's written apart from the algorithms so it
+// can judge them
+func OptimalCost(matrix datatypes.ScannedMatrix) (float32, bool) {
+	return cheapest(matrix, searchAlgorithms.CellCost)
+}
+
+// FewestMoves is the length of the shortest route that picks up the
+// passenger and then reaches the goal, whatever the traffic
+func FewestMoves(matrix datatypes.ScannedMatrix) (int, bool) {
+	moves, ok := cheapest(matrix, func(int) float32 { return 1 })
+	return int(moves), ok
+}
+
+// cheapest is Dijkstra with the cost of entering each kind of cell
+func cheapest(matrix datatypes.ScannedMatrix, cellCost func(int) float32) (float32, bool) {
+	start, hasStart := matrix.MainCoordinates["init"]
+	passenger, hasPassenger := matrix.MainCoordinates["passenger"]
+	goal, hasGoal := matrix.MainCoordinates["goal"]
+	if !hasStart || !hasPassenger || !hasGoal {
+		return 0, false
+	}
+
+	first := state{start, start == passenger}
+	best := map[state]float32{first: 0}
+	pending := &queue{{first, 0}}
+	for pending.Len() > 0 {
+		current := heap.Pop(pending).(entry)
+		if current.cost > best[current.state] {
+			continue
+		}
+		if current.state.pickedUp && current.state.cell == goal {
+			return current.cost, true
+		}
+		for _, move := range moves {
+			cell := datatypes.BoardCoordinate{X: current.state.cell.X + move.X, Y: current.state.cell.Y + move.Y}
+			if cell.X < 0 || cell.X >= len(matrix.Matrix) || cell.Y < 0 || cell.Y >= len(matrix.Matrix[cell.X]) {
+				continue
+			}
+			value := matrix.Matrix[cell.X][cell.Y]
+			if value == searchAlgorithms.WALL {
+				continue
+			}
+			next := state{cell, current.state.pickedUp || cell == passenger}
+			cost := current.cost + cellCost(value)
+			if known, seen := best[next]; !seen || cost < known {
+				best[next] = cost
+				heap.Push(pending, entry{next, cost})
+			}
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,64 @@
+package conformance
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Krud3/InteligenciaArtificial/src/datatypes"
+	"github.com/Krud3/InteligenciaArtificial/src/searchAlgorithms"
+	"github.com/Krud3/InteligenciaArtificial/src/utils"
+	"github.com/Krud3/InteligenciaArtificial/src/validator"
+)
+
+// Time each algorithm has to solve a generated map
+const propertyTimeout = 10 * time.Second
+
+// CheckProperties runs every algorithm over a map and compares them: all of
+// them agree with a reachability check on whether there's a solution, their
+// paths are valid and cost what they say, UCS and A* find the cheapest route
+// and BFS the one with fewest moves
+func CheckProperties(t testing.TB, matrix datatypes.ScannedMatrix) {
+	t.Helper()
+	solvable := utils.CheckMap(matrix.Matrix) == nil
+	results := make(map[string]datatypes.SearchResult)
+	for _, name := range searchAlgorithms.AlgorithmNames {
+		ctx, cancel := context.WithTimeout(context.Background(), propertyTimeout)
+		result, err := searchAlgorithms.RunLimited(ctx, name, matrix, nil, searchAlgorithms.Limits{})
+		cancel()
+		if err != nil {
+			t.Fatalf("%s on\n%s: %v", name, utils.FormatMatrix(matrix.Matrix), err)
+		}
+		if result.Status.LimitReached() || result.Status == datatypes.Cancelled {
+			t.Fatalf("%s didn't finish in %v: %s\n%s", name, propertyTimeout, result.Status, utils.FormatMatrix(matrix.Matrix))
+		}
+		if result.SolutionFound != solvable {
+			t.Fatalf("%s found a solution: %v, the map is solvable: %v\n%s", name, result.SolutionFound, solvable, utils.FormatMatrix(matrix.Matrix))
+		}
+		if solvable {
+			report := validator.Check(matrix, result.PathFound)
+			if !report.Valid() {
+				t.Fatalf("%s returned an invalid path %v: %s\n%s", name, result.PathFound, strings.Join(report.Problems, "; "), utils.FormatMatrix(matrix.Matrix))
+			}
+			if !report.CostMatches(result.Cost) {
+				t.Errorf("%s reported cost %v, its path costs %v\n%s", name, result.Cost, report.Cost, utils.FormatMatrix(matrix.Matrix))
+			}
+		}
+		results[name] = result
+	}
+	if !solvable {
+		return
+	}
+
+	best, _ := OptimalCost(matrix)
+	for _, name := range []string{searchAlgorithms.UniformCostName, searchAlgorithms.AStarName} {
+		if results[name].Cost != best {
+			t.Errorf("%s cost %v, the cheapest route costs %v\n%s", name, results[name].Cost, best, utils.FormatMatrix(matrix.Matrix))
+		}
+	}
+	fewest, _ := FewestMoves(matrix)
+	if moves := validator.Check(matrix, results[searchAlgorithms.BreadthFirstName].PathFound).Moves; moves != fewest {
+		t.Errorf("%s took %d moves, the shortest route has %d\n%s", searchAlgorithms.BreadthFirstName, moves, fewest, utils.FormatMatrix(matrix.Matrix))
+	}
+}
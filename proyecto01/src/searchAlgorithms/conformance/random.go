@@ -0,0 +1,89 @@
+package conformance
+
+import (
+	"math/rand"
+
+	"github.com/Krud3/InteligenciaArtificial/src/datatypes"
+	"github.com/Krud3/InteligenciaArtificial/src/searchAlgorithms"
+)
+
+// MaxSide is the most rows or columns of a generated map, enough for the
+// battery and small enough for the searches without memory
+const MaxSide = 10
+
+// cellKinds turns a byte into a cell, roads are the most common
+var cellKinds = [8]int{0, 0, 0, 0, searchAlgorithms.WALL, searchAlgorithms.WALL, searchAlgorithms.MIDCOST, searchAlgorithms.HEAVYCOST}
+
+// MapFromBytes builds a map out of any bytes, so the fuzzer can explore
+// maps. The first two bytes are the rows and the columns, the next three the
+// cells of the start, the passenger and the goal, and the rest the other
+// cells. Missing bytes are road
+func MapFromBytes(data []byte) datatypes.ScannedMatrix {
+	at := func(i int) int {
+		if i < len(data) {
+			return int(data[i])
+		}
+		return 0
+	}
+	rows, columns := 1+at(0)%MaxSide, 1+at(1)%MaxSide
+	if rows*columns < 3 {
+		columns = 3
+	}
+
+	matrix := make(datatypes.Matrix, rows)
+	for i := range matrix {
+		matrix[i] = make([]int, columns)
+		for j := range matrix[i] {
+			matrix[i][j] = cellKinds[at(5+i*columns+j)%len(cellKinds)]
+		}
+	}
+
+	// Two special cells never share a cell, the later one moves on to the next free one
+	taken := make(map[int]bool)
+	mainCoordinates := make(map[string]datatypes.BoardCoordinate)
+	for i, special := range []struct {
+		name  string
+		value int
+	}{{"init", searchAlgorithms.INIT_POSITION}, {"passenger", searchAlgorithms.DOG}, {"goal", searchAlgorithms.GOAL}} {
+		cell := at(2+i) % (rows * columns)
+		for taken[cell] {
+			cell = (cell + 1) % (rows * columns)
+		}
+		taken[cell] = true
+		coordinate := datatypes.BoardCoordinate{X: cell / columns, Y: cell % columns}
+		matrix[coordinate.X][coordinate.Y] = special.value
+		mainCoordinates[special.name] = coordinate
+	}
+	return datatypes.ScannedMatrix{Matrix: matrix, MainCoordinates: mainCoordinates}
+}
+
+// MapBytes is the inverse of MapFromBytes, it turns the maps of the battery
+// into seeds for the fuzzer. Maps bigger than MaxSide are cut
+func MapBytes(matrix datatypes.ScannedMatrix) []byte {
+	rows, columns := min(len(matrix.Matrix), MaxSide), min(len(matrix.Matrix[0]), MaxSide)
+	data := []byte{byte(rows - 1), byte(columns - 1)}
+	for _, name := range []string{"init", "passenger", "goal"} {
+		coordinate := matrix.MainCoordinates[name]
+		data = append(data, byte(min(coordinate.X, rows-1)*columns+min(coordinate.Y, columns-1)))
+	}
+	for i := 0; i < rows; i++ {
+		for j := 0; j < columns; j++ {
+			kind := 0
+			for k, value := range cellKinds {
+				if value == matrix.Matrix[i][j] {
+					kind = k
+					break
+				}
+			}
+			data = append(data, byte(kind))
+		}
+	}
+	return data
+}
+
+// RandomMap is a map of random size and cells
+func RandomMap(r *rand.Rand) datatypes.ScannedMatrix {
+	data := make([]byte, 5+MaxSide*MaxSide)
+	r.Read(data)
+	return MapFromBytes(data)
+}
@@ -0,0 +1,22 @@
+package searchAlgorithms_test
+
+import (
+	"testing"
+
+	"github.com/Krud3/InteligenciaArtificial/src/searchAlgorithms"
+	"github.com/Krud3/InteligenciaArtificial/src/searchAlgorithms/conformance"
+)
+
+// Algorithms that claim to find the cheapest route
+var optimal = map[string]bool{
+	searchAlgorithms.UniformCostName: true,
+	searchAlgorithms.AStarName:       true,
+}
+
+func TestConformance(t *testing.T) {
+	for _, name := range searchAlgorithms.AlgorithmNames {
+		t.Run(name, func(t *testing.T) {
+			conformance.Test(t, conformance.Named(name), conformance.Options{Optimal: optimal[name]})
+		})
+	}
+}
@@ -21,13 +21,14 @@ func (a *DepthSearch) LookForGoal(e *enviroment) datatypes.SearchResult {
 
 	start := time.Now()
 
-	pathToPassenger := []datatypes.BoardCoordinate{}
+	// Just the start if the passenger waits there
+	pathToPassenger := []datatypes.BoardCoordinate{initialPosition.CurrentPosition}
 	pathToGoal := []datatypes.BoardCoordinate{}
 
 	//fmt.Println("Iniciando búsqueda en profundidad...")
 	//fmt.Printf("Posición inicial del agente: [%d, %d]\n", initialPosition.CurrentPosition.X, initialPosition.CurrentPosition.Y)
 
-	for len(stack) > 0 {
+	for len(stack) > 0 && !e.notify.stopped() {
 		//fmt.Println("\nEstado actual de la pila:", stack)
 
 		currentStep := stack[len(stack)-1]
@@ -37,6 +38,7 @@ func (a *DepthSearch) LookForGoal(e *enviroment) datatypes.SearchResult {
 
 		if visited[currentStep.CurrentPosition] {
 			//fmt.Printf("Nodo [%d, %d] ya visitado, continuando...\n", currentStep.CurrentPosition.X, currentStep.CurrentPosition.Y)
+			e.notify.prune(stepNode(currentStep, e.agent.passenger))
 			continue
 		}
 		visited[currentStep.CurrentPosition] = true
@@ -48,6 +50,9 @@ func (a *DepthSearch) LookForGoal(e *enviroment) datatypes.SearchResult {
 		totalCost += cellCost
 		//fmt.Printf("Pasando por la casilla [%d, %d] con valor %d, costo acumulado: %.2f\n", currentStep.CurrentPosition.X, currentStep.CurrentPosition.Y, cellValue, totalCost)
 
+		reachedGoal := e.agent.passenger && e.board[currentStep.CurrentPosition.X][currentStep.CurrentPosition.Y] == 6
+		e.notify.goalTest(stepNode(currentStep, e.agent.passenger), reachedGoal)
+
 		// Phase 1: Find the passenger
 		if !e.agent.passenger && e.board[currentStep.CurrentPosition.X][currentStep.CurrentPosition.Y] == 5 {
 			//fmt.Println("Pasajero encontrado en la posición:", currentStep.CurrentPosition)
@@ -65,20 +70,25 @@ func (a *DepthSearch) LookForGoal(e *enviroment) datatypes.SearchResult {
 			visited = make(map[datatypes.BoardCoordinate]bool)
 
 			// start the search again from the passenger
-			stack = append(stack, datatypes.AgentStep{
+			passengerStep := datatypes.AgentStep{
 				PreviousPosition: datatypes.BoardCoordinate{
 					X: math.MaxInt,
 					Y: math.MaxInt,
 				},
 				CurrentPosition: currentStep.CurrentPosition,
 				Depth:           currentStep.Depth + 1,
-			})
+				// For the observers the new phase hangs from the node where the passenger was found
+				ID:       e.newID(),
+				ParentID: currentStep.ID,
+			}
+			stack = append(stack, passengerStep)
+			e.notify.generate(stepNode(passengerStep, true))
 			//fmt.Println("Reiniciando la búsqueda desde el pasajero.")
 			continue
 		}
 
 		// Phase 2: Find the goal
-		if e.agent.passenger && e.board[currentStep.CurrentPosition.X][currentStep.CurrentPosition.Y] == 6 {
+		if reachedGoal {
 			//fmt.Println("Destino encontrado en la posición:", currentStep.CurrentPosition)
 			end := time.Now()
 
@@ -88,13 +98,7 @@ func (a *DepthSearch) LookForGoal(e *enviroment) datatypes.SearchResult {
 			// Merege the path to the passenger with the path to the goal
 			combinedPath := append(pathToPassenger, pathToGoal...)
 
-			// Recalculate the cost of the combined path
-			finalCost := float32(0)
-			for _, step := range combinedPath {
-				cellValue := e.board[step.X][step.Y]
-				cellCost := getCellCost(cellValue)
-				finalCost += cellCost
-			}
+			finalCost := PathCost(e.board, initialPosition.CurrentPosition, combinedPath)
 
 			//fmt.Println("Camino encontrado desde el agente hasta el destino:")
 			//for _, step := range combinedPath {
@@ -109,7 +113,7 @@ func (a *DepthSearch) LookForGoal(e *enviroment) datatypes.SearchResult {
 				SolutionFound: true,
 				ExpandenNodes: expandedNodes,
 				TreeDepth:     len(combinedPath),
-				Cost:          finalCost - 1,
+				Cost:          finalCost,
 				TimeExe:       end.Sub(start),
 			}
 		}
@@ -118,20 +122,27 @@ func (a *DepthSearch) LookForGoal(e *enviroment) datatypes.SearchResult {
 		e.agent.position = currentStep
 		agentPerception := Percept(e.agent, e.board)
 		expandedNodes++
+		e.explored = append(e.explored, currentStep.CurrentPosition)
+		e.notify.expand(stepNode(currentStep, e.agent.passenger))
 
 		//fmt.Printf("Expandiendo vecinos del nodo [%d, %d]: %v\n", currentStep.CurrentPosition.X, currentStep.CurrentPosition.Y, agentPerception)
 
 		for _, perception := range agentPerception {
+			nextStep := datatypes.AgentStep{
+				Action:           perception.Direction,
+				Depth:            currentStep.Depth + 1,
+				CurrentPosition:  perception.Coordinate,
+				PreviousPosition: currentStep.CurrentPosition,
+				ID:               e.newID(),
+				ParentID:         currentStep.ID,
+			}
 			if !visited[perception.Coordinate] && perception.Coordinate != currentStep.PreviousPosition {
 				//fmt.Printf("Apilando nodo: [%d, %d]\n", perception.Coordinate.X, perception.Coordinate.Y)
-				stack = append(stack, datatypes.AgentStep{
-					Action:           perception.Direction,
-					Depth:            currentStep.Depth + 1,
-					CurrentPosition:  perception.Coordinate,
-					PreviousPosition: currentStep.CurrentPosition,
-				})
+				stack = append(stack, nextStep)
+				e.notify.generate(stepNode(nextStep, e.agent.passenger))
 			} else {
 				//fmt.Printf("Vecino [%d, %d] ya visitado o es el nodo previo, no apilado.\n", perception.Coordinate.X, perception.Coordinate.Y)
+				e.notify.prune(stepNode(nextStep, e.agent.passenger))
 			}
 		}
 	}
@@ -0,0 +1,126 @@
+package searchAlgorithms_test
+
+import (
+	"fmt"
+	"io/fs"
+	"math/rand"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Krud3/InteligenciaArtificial/battery"
+	"github.com/Krud3/InteligenciaArtificial/src/datatypes"
+	"github.com/Krud3/InteligenciaArtificial/src/searchAlgorithms/conformance"
+	"github.com/Krud3/InteligenciaArtificial/src/utils"
+)
+
+// Set it to turn the inputs the fuzzer saved in testdata into battery maps:
+//
+//	IA_SAVE_REGRESSIONS=1 go test -run TestSaveRegressions ./src/searchAlgorithms/
+const saveRegressionsEnv = "IA_SAVE_REGRESSIONS"
+
+const regressionsDir = "../../battery/regressions"
+
+// Number of random maps of TestRandomMaps, the fuzzer goes further
+const randomMaps = 300
+
+func regressionMaps(t testing.TB) map[string]datatypes.ScannedMatrix {
+	names, err := fs.Glob(battery.Regressions, "regressions/*.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	maps := make(map[string]datatypes.ScannedMatrix)
+	for _, name := range names {
+		file, err := battery.Regressions.Open(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		matrix, err := utils.ReadMatrix(file)
+		file.Close()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		maps[path.Base(name)] = matrix
+	}
+	return maps
+}
+
+func FuzzAlgorithms(f *testing.F) {
+	cases, err := conformance.BatteryCases()
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, edge := range conformance.EdgeMaps {
+		c, err := conformance.ParseCase(edge.Name, edge.Map)
+		if err != nil {
+			f.Fatal(err)
+		}
+		cases = append(cases, c)
+	}
+	for _, c := range cases {
+		f.Add(conformance.MapBytes(c.Matrix))
+	}
+	for _, matrix := range regressionMaps(f) {
+		f.Add(conformance.MapBytes(matrix))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		conformance.CheckProperties(t, conformance.MapFromBytes(data))
+	})
+}
+
+func TestRandomMaps(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < randomMaps; i++ {
+		conformance.CheckProperties(t, conformance.RandomMap(r))
+	}
+}
+
+func TestRegressionMaps(t *testing.T) {
+	for name, matrix := range regressionMaps(t) {
+		t.Run(name, func(t *testing.T) { conformance.CheckProperties(t, matrix) })
+	}
+}
+
+// TestSaveRegressions writes every input saved by the fuzzer, already
+// minimised, as a map in the regressions of the battery
+func TestSaveRegressions(t *testing.T) {
+	if os.Getenv(saveRegressionsEnv) == "" {
+		t.Skipf("set %s to save the fuzzer inputs as battery maps", saveRegressionsEnv)
+	}
+	files, err := filepath.Glob(filepath.Join("testdata", "fuzz", "FuzzAlgorithms", "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		data, err := readCorpusFile(file)
+		if err != nil {
+			t.Fatalf("%s: %v", file, err)
+		}
+		target := filepath.Join(regressionsDir, "fuzz-"+filepath.Base(file)+".txt")
+		if err := utils.SaveMatrix(target, conformance.MapFromBytes(data).Matrix); err != nil {
+			t.Fatal(err)
+		}
+		t.Logf("saved %s", target)
+	}
+}
+
+// readCorpusFile reads the []byte of a file of the fuzzer corpus
+func readCorpusFile(name string) ([]byte, error) {
+	content, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "go test fuzz v1") {
+		return nil, fmt.Errorf("not a fuzz corpus file")
+	}
+	literal := strings.TrimSuffix(strings.TrimPrefix(lines[1], "[]byte("), ")")
+	value, err := strconv.Unquote(literal)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(value), nil
+}
@@ -0,0 +1,89 @@
+package searchAlgorithms
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"time"
+
+	"github.com/Krud3/InteligenciaArtificial/src/datatypes"
+)
+
+// Limits bounds the resources of a search, zero values mean no limit
+type Limits struct {
+	Timeout       time.Duration
+	MaxExpansions int
+	// Bytes the heap may grow while the search runs. It's measured for the
+	// whole process, so searches running at the same time share it
+	MaxMemory uint64
+}
+
+// Expansions between two reads of the memory stats, reading them stops the world
+const memoryCheckInterval = 512
+
+// limiter tracks a running search against its context and limits, it's
+// shared by every copy of the notifier of the search
+type limiter struct {
+	ctx           context.Context
+	limits        Limits
+	started       time.Time
+	expansions    int
+	baseHeap      uint64
+	memoryChecked int                    // Expansions when the memory was last read
+	status        datatypes.SearchStatus // Why the search stopped, NoSolution while it runs
+}
+
+func newLimiter(ctx context.Context, limits Limits) *limiter {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	l := &limiter{ctx: ctx, limits: limits, started: time.Now()}
+	if limits.MaxMemory > 0 {
+		l.baseHeap = heapInUse()
+	}
+	return l
+}
+
+// expanded counts an expansion
+func (l *limiter) expanded() {
+	l.expansions++
+}
+
+// stopped tells if the search must end now, and remembers why. The
+// algorithms only ask while their frontier has nodes left, so a search that
+// runs out of them right at the expansion limit ends without a solution
+func (l *limiter) stopped() bool {
+	if l.status != datatypes.NoSolution {
+		return true
+	}
+	switch err := l.ctx.Err(); {
+	case errors.Is(err, context.DeadlineExceeded):
+		l.status = datatypes.TimeLimitReached
+	case err != nil:
+		l.status = datatypes.Cancelled
+	case l.limits.Timeout > 0 && time.Since(l.started) >= l.limits.Timeout:
+		l.status = datatypes.TimeLimitReached
+	case l.limits.MaxExpansions > 0 && l.expansions >= l.limits.MaxExpansions:
+		l.status = datatypes.ExpansionLimitReached
+	case l.limits.MaxMemory > 0 && l.expansions >= l.memoryChecked+memoryCheckInterval:
+		l.memoryChecked = l.expansions
+		if heapInUse() > l.baseHeap+l.limits.MaxMemory {
+			l.status = datatypes.MemoryLimitReached
+		}
+	}
+	return l.status != datatypes.NoSolution
+}
+
+// finalStatus is the status of a search that returned
+func (l *limiter) finalStatus(solutionFound bool) datatypes.SearchStatus {
+	if solutionFound {
+		return datatypes.Solved
+	}
+	return l.status
+}
+
+func heapInUse() uint64 {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return stats.HeapAlloc
+}
@@ -0,0 +1,52 @@
+package searchAlgorithms_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Krud3/InteligenciaArtificial/src/datatypes"
+	"github.com/Krud3/InteligenciaArtificial/src/searchAlgorithms"
+	"github.com/Krud3/InteligenciaArtificial/src/utils"
+)
+
+// A wall cuts the road to the passenger, every algorithm expands the four
+// cells before it and runs out of nodes
+const walledCorridor = "2 0 3 0 1 5 6"
+
+func TestExpansionLimitOnExhaustedSearch(t *testing.T) {
+	matrix, err := utils.ReadMatrix(strings.NewReader(walledCorridor))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range searchAlgorithms.AlgorithmNames {
+		t.Run(name, func(t *testing.T) {
+			unlimited, err := searchAlgorithms.RunLimited(context.Background(), name, matrix, nil, searchAlgorithms.Limits{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if unlimited.Status != datatypes.NoSolution || unlimited.ExpandenNodes == 0 {
+				t.Fatalf("status %s after %d expansions, expected no solution", unlimited.Status, unlimited.ExpandenNodes)
+			}
+			for _, test := range []struct {
+				maxExpansions int
+				status        datatypes.SearchStatus
+			}{
+				// The last expansion empties the frontier, the search was exhaustive
+				{unlimited.ExpandenNodes, datatypes.NoSolution},
+				{unlimited.ExpandenNodes - 1, datatypes.ExpansionLimitReached},
+			} {
+				if test.maxExpansions == 0 {
+					continue
+				}
+				result, err := searchAlgorithms.RunLimited(context.Background(), name, matrix, nil, searchAlgorithms.Limits{MaxExpansions: test.maxExpansions})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if result.Status != test.status {
+					t.Errorf("at most %d expansions: status %s, expected %s", test.maxExpansions, result.Status, test.status)
+				}
+			}
+		})
+	}
+}
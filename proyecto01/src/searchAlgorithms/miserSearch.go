@@ -17,40 +17,50 @@ func (m *MiserSearch) LookForGoal(env *Environment) SearchResult {
 	openList := &PriorityQueue{compare: compare}
 	heap.Init(openList)
 	closedList := make(map[State]bool)
+	notify := env.notify()
 
 	startNode := &Node{
 		Position:             env.InitPosition,
 		G:                    0,
 		H:                    heuristic(&Node{Position: env.InitPosition}, env),
 		Depth:                0,
-		HasPickedUpPassenger: false,
+		HasPickedUpPassenger: env.InitPosition == env.DogPosition,
+		ID:                   env.newID(),
 	}
 
 	heap.Push(openList, startNode)
 
 	var expandedNodes int
 	var maxDepth int
+	var explored []Position
 
-	for openList.Len() > 0 {
+	for openList.Len() > 0 && !notify.stopped() {
 		currentNode := heap.Pop(openList).(*Node)
 		expandedNodes++
+		explored = append(explored, currentNode.Position)
+		notify.expand(treeNode(currentNode))
 		if currentNode.Depth > maxDepth {
 			maxDepth = currentNode.Depth
 		}
 
-		if currentNode.Position == env.GoalPosition && currentNode.HasPickedUpPassenger {
+		reached := currentNode.Position == env.GoalPosition && currentNode.HasPickedUpPassenger
+		notify.goalTest(treeNode(currentNode), reached)
+		if reached {
 			totalPath := reconstructPathI(currentNode)
-			totalCost := currentNode.G
+			totalCost := env.pathCost(totalPath)
 			timeExecuted := time.Since(startTime)
 
-			return SearchResult{
+			result := SearchResult{
 				SolutionFound: true,
 				ExpandedNodes: expandedNodes,
 				TreeDepth:     maxDepth,
 				Cost:          totalCost,
 				TimeExecuted:  timeExecuted,
 				Path:          totalPath,
+				Explored:      explored,
 			}
+			notify.solution(result.toDatatypes())
+			return result
 		}
 
 		state := State{
@@ -58,6 +68,7 @@ func (m *MiserSearch) LookForGoal(env *Environment) SearchResult {
 			HasPickedUpPassenger: currentNode.HasPickedUpPassenger,
 		}
 		if closedList[state] {
+			notify.prune(treeNode(currentNode))
 			continue
 		}
 		closedList[state] = true
@@ -70,18 +81,23 @@ func (m *MiserSearch) LookForGoal(env *Environment) SearchResult {
 				HasPickedUpPassenger: successor.HasPickedUpPassenger,
 			}
 			if closedList[state] {
+				notify.prune(treeNode(successor))
 				continue
 			}
 
 			heap.Push(openList, successor)
+			notify.generate(treeNode(successor))
 		}
 	}
 
 	timeExecuted := time.Since(startTime)
-	return SearchResult{
+	result := SearchResult{
 		SolutionFound: false,
 		ExpandedNodes: expandedNodes,
 		TreeDepth:     maxDepth,
 		TimeExecuted:  timeExecuted,
+		Explored:      explored,
 	}
+	notify.solution(result.toDatatypes())
+	return result
 }
@@ -0,0 +1,244 @@
+package searchAlgorithms
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/Krud3/InteligenciaArtificial/src/datatypes"
+)
+
+// SearchNode is what an observer knows about a node of the search tree
+type SearchNode struct {
+	// Identifies the node within its search, every event about it carries it
+	ID       int
+	Position datatypes.BoardCoordinate
+	// ID and position of the node that generated this one, only valid if HasParent
+	ParentID             int
+	Parent               datatypes.BoardCoordinate
+	HasParent            bool
+	G, H, F              float32
+	Depth                int
+	HasPickedUpPassenger bool
+}
+
+// Restarts tells if the node starts the search over from the passenger, the
+// searches that do it drop the nodes left in their frontier
+func (n SearchNode) Restarts() bool {
+	return n.HasPickedUpPassenger && n.HasParent && n.Parent == n.Position
+}
+
+// SearchObserver receives the events of a search while it runs, so the
+// algorithms can be watched without changing them
+type SearchObserver interface {
+	// A node is taken out of the frontier to generate its successors
+	OnExpand(node SearchNode)
+	// A node is pushed into the frontier
+	OnGenerate(node SearchNode)
+	// A node is discarded because its state was already visited
+	OnPrune(node SearchNode)
+	// A node is checked against the goal
+	OnGoalTest(node SearchNode, reached bool)
+	// The search finished, SolutionFound tells if it reached the goal
+	OnSolution(result datatypes.SearchResult)
+}
+
+// BaseObserver ignores every event, embed it to implement only some of them
+type BaseObserver struct{}
+
+func (BaseObserver) OnExpand(SearchNode)               {}
+func (BaseObserver) OnGenerate(SearchNode)             {}
+func (BaseObserver) OnPrune(SearchNode)                {}
+func (BaseObserver) OnGoalTest(SearchNode, bool)       {}
+func (BaseObserver) OnSolution(datatypes.SearchResult) {}
+
+// notifier forwards the events to an observer that may be nil, and tells the
+// algorithms when the search was cancelled or ran out of its limits
+type notifier struct {
+	observer SearchObserver
+	limits   *limiter
+}
+
+// stopped tells if the search must end now, without a solution
+func (n notifier) stopped() bool {
+	return n.limits != nil && n.limits.stopped()
+}
+
+// status is the status of a search that returned
+func (n notifier) status(solutionFound bool) datatypes.SearchStatus {
+	if n.limits == nil {
+		if solutionFound {
+			return datatypes.Solved
+		}
+		return datatypes.NoSolution
+	}
+	return n.limits.finalStatus(solutionFound)
+}
+
+func (n notifier) expand(node SearchNode) {
+	if n.limits != nil {
+		n.limits.expanded()
+	}
+	if n.observer != nil {
+		n.observer.OnExpand(node)
+	}
+}
+
+func (n notifier) generate(node SearchNode) {
+	if n.observer != nil {
+		n.observer.OnGenerate(node)
+	}
+}
+
+func (n notifier) prune(node SearchNode) {
+	if n.observer != nil {
+		n.observer.OnPrune(node)
+	}
+}
+
+func (n notifier) goalTest(node SearchNode, reached bool) {
+	if n.observer != nil {
+		n.observer.OnGoalTest(node, reached)
+	}
+}
+
+func (n notifier) solution(result datatypes.SearchResult) {
+	result.Status = n.status(result.SolutionFound)
+	if n.observer != nil {
+		n.observer.OnSolution(result)
+	}
+}
+
+// stepNode describes an AgentStep of the uninformed algorithms
+func stepNode(step datatypes.AgentStep, hasPassenger bool) SearchNode {
+	node := SearchNode{
+		ID:                   step.ID,
+		Position:             step.CurrentPosition,
+		G:                    float32(step.Cost),
+		F:                    float32(step.Cost),
+		Depth:                step.Depth,
+		HasPickedUpPassenger: hasPassenger,
+	}
+	if step.ParentID != 0 {
+		node.ParentID, node.Parent, node.HasParent = step.ParentID, step.PreviousPosition, true
+		if step.PreviousPosition.X == math.MaxInt && step.PreviousPosition.Y == math.MaxInt {
+			// The search restarted from the passenger, the new phase hangs
+			// from the node where it was found, in the same cell
+			node.Parent = step.CurrentPosition
+		}
+	}
+	return node
+}
+
+// treeNode describes a Node of the algorithms that work over an Environment
+func treeNode(node *Node) SearchNode {
+	searchNode := SearchNode{
+		ID:                   node.ID,
+		Position:             datatypes.BoardCoordinate{X: node.Position.X, Y: node.Position.Y},
+		G:                    node.G,
+		H:                    node.H,
+		F:                    node.F,
+		Depth:                node.Depth,
+		HasPickedUpPassenger: node.HasPickedUpPassenger,
+	}
+	if node.Parent != nil {
+		searchNode.ParentID = node.Parent.ID
+		searchNode.Parent = datatypes.BoardCoordinate{X: node.Parent.Position.X, Y: node.Parent.Position.Y}
+		searchNode.HasParent = true
+	}
+	return searchNode
+}
+
+// positionNode describes a bare position, used by AmplitudeSearch. A
+// parentID of 0 is a root
+func positionNode(id int, position Position, parentID int, parent Position, depth int) SearchNode {
+	node := SearchNode{
+		ID:        id,
+		Position:  datatypes.BoardCoordinate{X: position.X, Y: position.Y},
+		HasParent: parentID != 0,
+		Depth:     depth,
+	}
+	if node.HasParent {
+		node.ParentID = parentID
+		node.Parent = datatypes.BoardCoordinate{X: parent.X, Y: parent.Y}
+	}
+	return node
+}
+
+// LogObserver writes one line per event, useful to follow a search from the terminal
+type LogObserver struct {
+	Writer io.Writer
+}
+
+func (l LogObserver) OnExpand(node SearchNode) {
+	fmt.Fprintf(l.Writer, "expand   %s\n", node)
+}
+
+func (l LogObserver) OnGenerate(node SearchNode) {
+	fmt.Fprintf(l.Writer, "generate %s\n", node)
+}
+
+func (l LogObserver) OnPrune(node SearchNode) {
+	fmt.Fprintf(l.Writer, "prune    %s\n", node)
+}
+
+func (l LogObserver) OnGoalTest(node SearchNode, reached bool) {
+	fmt.Fprintf(l.Writer, "goal?    %s reached=%t\n", node, reached)
+}
+
+func (l LogObserver) OnSolution(result datatypes.SearchResult) {
+	fmt.Fprintf(l.Writer, "solution found=%t status=%s cost=%.0f expanded=%d\n", result.SolutionFound, result.Status, result.Cost, result.ExpandenNodes)
+}
+
+func (n SearchNode) String() string {
+	parent := "-"
+	if n.HasParent {
+		parent = fmt.Sprintf("(%d,%d)", n.Parent.X, n.Parent.Y)
+	}
+	return fmt.Sprintf("(%d,%d) parent=%s g=%.0f h=%.0f f=%.0f depth=%d passenger=%t",
+		n.Position.X, n.Position.Y, parent, n.G, n.H, n.F, n.Depth, n.HasPickedUpPassenger)
+}
+
+// multiObserver forwards every event to several observers
+type multiObserver []SearchObserver
+
+// Observers combines several observers into one, nil observers are skipped
+func Observers(observers ...SearchObserver) SearchObserver {
+	var combined multiObserver
+	for _, observer := range observers {
+		if observer != nil {
+			combined = append(combined, observer)
+		}
+	}
+	return combined
+}
+
+func (m multiObserver) OnExpand(node SearchNode) {
+	for _, observer := range m {
+		observer.OnExpand(node)
+	}
+}
+
+func (m multiObserver) OnGenerate(node SearchNode) {
+	for _, observer := range m {
+		observer.OnGenerate(node)
+	}
+}
+
+func (m multiObserver) OnPrune(node SearchNode) {
+	for _, observer := range m {
+		observer.OnPrune(node)
+	}
+}
+
+func (m multiObserver) OnGoalTest(node SearchNode, reached bool) {
+	for _, observer := range m {
+		observer.OnGoalTest(node, reached)
+	}
+}
+
+func (m multiObserver) OnSolution(result datatypes.SearchResult) {
+	for _, observer := range m {
+		observer.OnSolution(result)
+	}
+}
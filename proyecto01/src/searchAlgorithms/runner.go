@@ -0,0 +1,88 @@
+package searchAlgorithms
+
+import (
+	"context"
+
+	"github.com/Krud3/InteligenciaArtificial/src/datatypes"
+	"github.com/Krud3/InteligenciaArtificial/src/i18n"
+)
+
+// Names of the algorithms, the same ones shown in the game menu
+const (
+	MiserName        = "Avaro"
+	AStarName        = "A*"
+	BreadthFirstName = "Breadth First Algorithm"
+	DepthName        = "DepthSearch"
+	UniformCostName  = "Uniform Cost Search"
+)
+
+// AlgorithmNames lists every algorithm that Run can execute
+var AlgorithmNames = []string{MiserName, AStarName, BreadthFirstName, DepthName, UniformCostName}
+
+// Run executes the algorithm with the given name over a scanned matrix
+func Run(algorithmName string, scannedMatrix datatypes.ScannedMatrix) (datatypes.SearchResult, error) {
+	return RunObserved(algorithmName, scannedMatrix, nil)
+}
+
+// RunObserved is Run with an observer that receives the events of the search
+func RunObserved(algorithmName string, scannedMatrix datatypes.ScannedMatrix, observer SearchObserver) (datatypes.SearchResult, error) {
+	return RunContext(context.Background(), algorithmName, scannedMatrix, observer)
+}
+
+// RunContext is RunObserved that stops the search when ctx is cancelled, in
+// that case the error is the one of the context
+func RunContext(ctx context.Context, algorithmName string, scannedMatrix datatypes.ScannedMatrix, observer SearchObserver) (datatypes.SearchResult, error) {
+	return RunLimited(ctx, algorithmName, scannedMatrix, observer, Limits{})
+}
+
+// RunLimited is RunContext that also stops the search when it reaches one of
+// the limits. Reaching a limit isn't an error, the Status of the result tells
+// it apart from a search without solution
+func RunLimited(ctx context.Context, algorithmName string, scannedMatrix datatypes.ScannedMatrix, observer SearchObserver, limits Limits) (datatypes.SearchResult, error) {
+	result, err := run(ctx, algorithmName, scannedMatrix, observer, limits)
+	if err == nil && result.Status == datatypes.Cancelled {
+		return result, ctx.Err()
+	}
+	return result, err
+}
+
+func run(ctx context.Context, algorithmName string, scannedMatrix datatypes.ScannedMatrix, observer SearchObserver, limits Limits) (datatypes.SearchResult, error) {
+	switch algorithmName {
+	case BreadthFirstName:
+		return StartSearchContext(ctx, 1, scannedMatrix, observer, limits), nil
+	case UniformCostName:
+		return StartSearchContext(ctx, 2, scannedMatrix, observer, limits), nil
+	case DepthName:
+		return StartSearchContext(ctx, 3, scannedMatrix, observer, limits), nil
+	case AStarName:
+		return runInformed(ctx, new(AStarSearch), scannedMatrix, observer, limits)
+	case MiserName:
+		return runInformed(ctx, new(MiserSearch), scannedMatrix, observer, limits)
+	default:
+		return datatypes.SearchResult{}, i18n.Errorf("error.unknownAlgorithm", algorithmName)
+	}
+}
+
+// runInformed runs one of the algorithms that work over an Environment
+func runInformed(ctx context.Context, algorithm SearchAlgorithm, scannedMatrix datatypes.ScannedMatrix, observer SearchObserver, limits Limits) (datatypes.SearchResult, error) {
+	env, err := NewScannedEnvironment(scannedMatrix)
+	if err != nil {
+		return datatypes.SearchResult{}, err
+	}
+	env.Observer = observer
+	env.Context = ctx
+	env.Limits = limits
+	agent := NewAgent(env.InitPosition, algorithm)
+	result := agent.SearchAlgorithm.LookForGoal(env)
+	converted := result.toDatatypes()
+	converted.Status = env.notify().status(converted.SolutionFound)
+	return converted, nil
+}
+
+func toBoardCoordinates(positions []Position) []datatypes.BoardCoordinate {
+	coordinates := make([]datatypes.BoardCoordinate, len(positions))
+	for i, position := range positions {
+		coordinates[i] = datatypes.BoardCoordinate{X: position.X, Y: position.Y}
+	}
+	return coordinates
+}
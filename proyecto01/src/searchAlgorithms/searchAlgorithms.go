@@ -1,11 +1,13 @@
 package searchAlgorithms
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"time"
 
 	"github.com/Krud3/InteligenciaArtificial/src/datatypes"
+	"github.com/Krud3/InteligenciaArtificial/src/i18n"
 )
 
 const (
@@ -25,6 +27,8 @@ type Node struct {
 	F                    float32 // Costo total (F = G + H)
 	Depth                int
 	HasPickedUpPassenger bool
+	// Identifica el nodo en la búsqueda, lo reciben los observadores
+	ID int
 }
 
 type PriorityQueue struct {
@@ -67,14 +71,22 @@ type Position struct {
 
 // Environment representa el entorno donde el agente se moverá.
 type Environment struct {
-	Matrix       *[10][10]int
+	Matrix       [][]int
 	InitPosition Position
 	DogPosition  Position
 	GoalPosition Position
+	// Optional, receives the events of the search
+	Observer SearchObserver
+	// Optional, the search stops without a solution when it's done
+	Context context.Context
+	// Optional, the search stops without a solution when it reaches one
+	Limits  Limits
+	limiter *limiter
+	nodes   int // IDs given to the nodes of the search
 }
 
 // NewEnvironment crea un nuevo entorno a partir de una matriz.
-func NewEnvironment(matrix [10][10]int) (*Environment, error) {
+func NewEnvironment(matrix [][]int) (*Environment, error) {
 	var initPos, dogPos, goalPos Position
 	foundInit, foundDog, foundGoal := false, false, false
 
@@ -95,17 +107,44 @@ func NewEnvironment(matrix [10][10]int) (*Environment, error) {
 	}
 
 	if !foundInit || !foundDog || !foundGoal {
-		return nil, fmt.Errorf("environment must have init, dog, and goal positions")
+		return nil, i18n.Errorf("error.environment")
 	}
 
 	return &Environment{
-		Matrix:       &matrix,
+		Matrix:       matrix,
 		InitPosition: initPos,
 		DogPosition:  dogPos,
 		GoalPosition: goalPos,
 	}, nil
 }
 
+// NewScannedEnvironment builds the environment from the coordinates found
+// when the map was read instead of looking for the cells again, so the
+// passenger can wait at the start, where the matrix only shows the start
+func NewScannedEnvironment(scannedMatrix datatypes.ScannedMatrix) (*Environment, error) {
+	matrix, err := ValidateMatrix(scannedMatrix.Matrix)
+	if err != nil {
+		return nil, err
+	}
+	init, foundInit := scannedMatrix.MainCoordinates["init"]
+	dog, foundDog := scannedMatrix.MainCoordinates["passenger"]
+	goal, foundGoal := scannedMatrix.MainCoordinates["goal"]
+	if !foundInit || !foundDog || !foundGoal {
+		return nil, i18n.Errorf("error.environment")
+	}
+	return &Environment{
+		Matrix:       matrix,
+		InitPosition: Position{X: init.X, Y: init.Y},
+		DogPosition:  Position{X: dog.X, Y: dog.Y},
+		GoalPosition: Position{X: goal.X, Y: goal.Y},
+	}, nil
+}
+
+// pathCost is PathCost for the algorithms that work over an Environment
+func (env *Environment) pathCost(path []Position) float32 {
+	return PathCost(env.Matrix, datatypes.BoardCoordinate{X: env.InitPosition.X, Y: env.InitPosition.Y}, toBoardCoordinates(path))
+}
+
 // Perception representa la percepción del agente en las cuatro direcciones.
 type Perception struct {
 	Up, Right, Down, Left bool
@@ -125,6 +164,15 @@ type enviroment struct {
 	passengerPosition datatypes.BoardCoordinate
 	board             [][]int
 	totalGoal         int
+	explored          []datatypes.BoardCoordinate
+	notify            notifier
+	nodes             int // IDs given to the steps of the search
+}
+
+// newID identifies a new step of the search, the first one is 1
+func (e *enviroment) newID() int {
+	e.nodes++
+	return e.nodes
 }
 
 // SearchAlgorithm is the interface that the search algorithms must implement
@@ -165,7 +213,7 @@ func Percept(a agent, board [][]int) []datatypes.CoordinateMovement {
 		if testingCoordinate.X >= 0 &&
 			testingCoordinate.Y >= 0 &&
 			testingCoordinate.X < len(board) &&
-			testingCoordinate.Y < len(board) &&
+			testingCoordinate.Y < len(board[testingCoordinate.X]) &&
 			board[testingCoordinate.X][testingCoordinate.Y] != 1 {
 			canMove = append(canMove, datatypes.CoordinateMovement{
 				Direction:  contiguous.Direction,
@@ -209,6 +257,17 @@ func reconstructPath(parentNodes []datatypes.AgentStep, endStep datatypes.AgentS
 }
 
 func StartSearch(strategy int, scannedMatrix datatypes.ScannedMatrix) datatypes.SearchResult {
+	return StartObservedSearch(strategy, scannedMatrix, nil)
+}
+
+// StartObservedSearch is StartSearch with an observer that receives the events of the search
+func StartObservedSearch(strategy int, scannedMatrix datatypes.ScannedMatrix, observer SearchObserver) datatypes.SearchResult {
+	return StartSearchContext(context.Background(), strategy, scannedMatrix, observer, Limits{})
+}
+
+// StartSearchContext is StartObservedSearch that gives up when ctx is
+// cancelled or a limit is reached, the Status of the result tells which
+func StartSearchContext(ctx context.Context, strategy int, scannedMatrix datatypes.ScannedMatrix, observer SearchObserver, limits Limits) datatypes.SearchResult {
 
 	var searchStrategy SearchAgorithm
 
@@ -222,7 +281,7 @@ func StartSearch(strategy int, scannedMatrix datatypes.ScannedMatrix) datatypes.
 	case 4:
 		searchStrategy = &BreadthFirstSearch{}
 	default:
-		fmt.Println("Unknown strategy")
+		fmt.Println(i18n.T("log.unknownStrategy"))
 		return datatypes.SearchResult{}
 	}
 
@@ -238,20 +297,30 @@ func StartSearch(strategy int, scannedMatrix datatypes.ScannedMatrix) datatypes.
 				Y: math.MaxInt,
 			},
 		}
+		// The passenger may be waiting at the start, then there's no cell to find it in
+		passenger, hasPassenger := scannedMatrix.MainCoordinates["passenger"]
 		agent := agent{
 			initialStep,
-			false,
+			hasPassenger && passenger == initialPosition,
 			searchStrategy,
 		}
-		result := agent.searchAlgorithm.LookForGoal(&enviroment{
+		env := &enviroment{
 			agent,
 			datatypes.BoardCoordinate{X: math.MaxInt, Y: math.MaxInt},
 			scannedMatrix.Matrix,
-			0},
-		)
+			0,
+			nil,
+			notifier{observer, newLimiter(ctx, limits)},
+			0,
+		}
+		env.agent.position.ID = env.newID()
+		result := agent.searchAlgorithm.LookForGoal(env)
+		result.Explored = env.explored
+		result.Status = env.notify.status(result.SolutionFound)
+		env.notify.solution(result)
 		return result
 	} else {
-		fmt.Println("Initial position not found")
+		fmt.Println(i18n.T("log.noInit"))
 		return datatypes.SearchResult{}
 	}
 }
@@ -264,6 +333,34 @@ type SearchResult struct {
 	Cost          float32
 	TimeExecuted  time.Duration
 	Path          []Position
+	Explored      []Position
+}
+
+// toDatatypes converts the result to the type used by the rest of the game
+func (r SearchResult) toDatatypes() datatypes.SearchResult {
+	return datatypes.SearchResult{
+		PathFound:     toBoardCoordinates(r.Path),
+		SolutionFound: r.SolutionFound,
+		ExpandenNodes: r.ExpandedNodes,
+		TreeDepth:     r.TreeDepth,
+		Cost:          r.Cost,
+		TimeExe:       r.TimeExecuted,
+		Explored:      toBoardCoordinates(r.Explored),
+	}
+}
+
+// newID identifies a new node of the search, the first one is 1
+func (env *Environment) newID() int {
+	env.nodes++
+	return env.nodes
+}
+
+// notify returns the notifier of the environment observer
+func (env *Environment) notify() notifier {
+	if env.limiter == nil {
+		env.limiter = newLimiter(env.Context, env.Limits)
+	}
+	return notifier{env.Observer, env.limiter}
 }
 
 // SearchAlgorithm es la interfaz que deben implementar los algoritmos de búsqueda.
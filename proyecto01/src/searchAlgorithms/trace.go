@@ -0,0 +1,57 @@
+package searchAlgorithms
+
+import "github.com/Krud3/InteligenciaArtificial/src/datatypes"
+
+type EventKind int
+
+const (
+	ExpandEvent EventKind = iota
+	GenerateEvent
+	PruneEvent
+	GoalTestEvent
+)
+
+// SearchEvent is one of the events received by a SearchObserver
+type SearchEvent struct {
+	Kind    EventKind
+	Node    SearchNode
+	Reached bool // Only for GoalTestEvent
+}
+
+// SearchTrace is an observer that records every event of a search in order,
+// so it can be replayed after the search finished
+type SearchTrace struct {
+	Events []SearchEvent
+	Result datatypes.SearchResult
+}
+
+func (t *SearchTrace) OnExpand(node SearchNode) {
+	t.Events = append(t.Events, SearchEvent{Kind: ExpandEvent, Node: node})
+}
+
+func (t *SearchTrace) OnGenerate(node SearchNode) {
+	t.Events = append(t.Events, SearchEvent{Kind: GenerateEvent, Node: node})
+}
+
+func (t *SearchTrace) OnPrune(node SearchNode) {
+	t.Events = append(t.Events, SearchEvent{Kind: PruneEvent, Node: node})
+}
+
+func (t *SearchTrace) OnGoalTest(node SearchNode, reached bool) {
+	t.Events = append(t.Events, SearchEvent{Kind: GoalTestEvent, Node: node, Reached: reached})
+}
+
+func (t *SearchTrace) OnSolution(result datatypes.SearchResult) {
+	t.Result = result
+}
+
+// Expansions returns how many expand events were recorded
+func (t *SearchTrace) Expansions() int {
+	count := 0
+	for _, event := range t.Events {
+		if event.Kind == ExpandEvent {
+			count++
+		}
+	}
+	return count
+}
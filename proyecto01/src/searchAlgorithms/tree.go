@@ -0,0 +1,66 @@
+package searchAlgorithms
+
+// TreeNode is a node of the search tree rebuilt from a SearchTrace
+type TreeNode struct {
+	ID     int
+	Parent int // -1 for the roots
+	Node   SearchNode
+	// Position in the expansion order starting at 1, 0 if it was never expanded
+	ExpansionOrder int
+	// Discarded because its state was already visited
+	Pruned bool
+	// Part of the branch that reaches the goal
+	OnSolution bool
+	// Depth in the tree, unlike Node.Depth it doesn't restart with the passenger
+	TreeDepth int
+}
+
+// SearchTree is the tree generated by a search, in creation order
+type SearchTree struct {
+	Nodes []TreeNode
+}
+
+// BuildSearchTree rebuilds the generated tree from the recorded events. Every
+// event carries the ID of its node and of the node that generated it, so the
+// tree is linked as the algorithm built it
+func BuildSearchTree(trace *SearchTrace) *SearchTree {
+	tree := &SearchTree{}
+	index := make(map[int]int) // Position in tree.Nodes of each node ID
+
+	// node returns the tree node of a search node, added the first time it's seen
+	node := func(searchNode SearchNode) int {
+		if id, found := index[searchNode.ID]; found {
+			return id
+		}
+		parent, depth := -1, 0
+		if id, found := index[searchNode.ParentID]; searchNode.HasParent && found {
+			parent, depth = id, tree.Nodes[id].TreeDepth+1
+		}
+		id := len(tree.Nodes)
+		tree.Nodes = append(tree.Nodes, TreeNode{ID: id, Parent: parent, Node: searchNode, TreeDepth: depth})
+		index[searchNode.ID] = id
+		return id
+	}
+
+	goal := -1
+	expansions := 0
+	for _, event := range trace.Events {
+		id := node(event.Node)
+		switch event.Kind {
+		case ExpandEvent:
+			expansions++
+			tree.Nodes[id].ExpansionOrder = expansions
+		case GoalTestEvent:
+			if event.Reached {
+				goal = id
+			}
+		case PruneEvent:
+			tree.Nodes[id].Pruned = true
+		}
+	}
+
+	for id := goal; id >= 0; id = tree.Nodes[id].Parent {
+		tree.Nodes[id].OnSolution = true
+	}
+	return tree
+}
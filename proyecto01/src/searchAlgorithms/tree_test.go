@@ -0,0 +1,114 @@
+package searchAlgorithms_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Krud3/InteligenciaArtificial/src/datatypes"
+	"github.com/Krud3/InteligenciaArtificial/src/searchAlgorithms"
+	"github.com/Krud3/InteligenciaArtificial/src/utils"
+)
+
+// The passenger waits at a dead end, so the route drives back through the
+// same cells with the passenger on board
+const treeMap = "6 1 1\n2 0 5"
+
+func TestBuildSearchTree(t *testing.T) {
+	matrix, err := utils.ReadMatrix(strings.NewReader(treeMap))
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := matrix.MainCoordinates["init"]
+	for _, test := range []struct {
+		name     string
+		restarts int // Nodes that start the search over from the passenger
+	}{
+		{searchAlgorithms.BreadthFirstName, 1},
+		{searchAlgorithms.UniformCostName, 1},
+		{searchAlgorithms.AStarName, 0},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			trace := &searchAlgorithms.SearchTrace{}
+			result, err := searchAlgorithms.RunObserved(test.name, matrix, trace)
+			if err != nil || !result.SolutionFound {
+				t.Fatalf("no solution: %v", err)
+			}
+			tree := searchAlgorithms.BuildSearchTree(trace)
+
+			pruned := make(map[int]bool)
+			for _, event := range trace.Events {
+				if event.Kind == searchAlgorithms.PruneEvent {
+					pruned[event.Node.ID] = true
+				}
+			}
+			roots := 0
+			for _, node := range tree.Nodes {
+				if node.Pruned != pruned[node.Node.ID] {
+					t.Errorf("node %d pruned %v, its events say %v", node.Node.ID, node.Pruned, pruned[node.Node.ID])
+				}
+				if node.Pruned && node.ExpansionOrder > 0 {
+					t.Errorf("node %d was pruned and expanded", node.Node.ID)
+				}
+				if node.Parent < 0 {
+					roots++
+					if node.Node.HasParent || node.Node.Position != start {
+						t.Errorf("node %d at %v is a root", node.Node.ID, node.Node.Position)
+					}
+					continue
+				}
+				parent := tree.Nodes[node.Parent]
+				if parent.Node.ID != node.Node.ParentID || parent.Node.Position != node.Node.Parent {
+					t.Errorf("node %d hangs from node %d at %v, it was generated by %d at %v",
+						node.Node.ID, parent.Node.ID, parent.Node.Position, node.Node.ParentID, node.Node.Parent)
+				}
+				// Only the restart from the passenger stays in the same cell
+				restart := parent.Node.Position == node.Node.Position && !parent.Node.HasPickedUpPassenger && node.Node.HasPickedUpPassenger
+				if parent.ExpansionOrder == 0 && !restart {
+					t.Errorf("node %d hangs from node %d, never expanded", node.Node.ID, parent.Node.ID)
+				}
+				if node.TreeDepth != parent.TreeDepth+1 {
+					t.Errorf("node %d at depth %d, its parent at %d", node.Node.ID, node.TreeDepth, parent.TreeDepth)
+				}
+			}
+			if roots != 1 {
+				t.Errorf("%d roots", roots)
+			}
+			// The same cell is in the tree with and without the passenger
+			states := make(map[bool]bool)
+			for _, node := range tree.Nodes {
+				if node.Node.Position == (datatypes.BoardCoordinate{X: 1, Y: 1}) {
+					states[node.Node.HasPickedUpPassenger] = true
+				}
+			}
+			if len(states) != 2 {
+				t.Errorf("cell (1, 1) is in the tree with the passenger states %v", states)
+			}
+
+			// The solution branch is the path found, the searches that restart
+			// from the passenger have two nodes in its cell
+			var branch []datatypes.BoardCoordinate
+			onSolution := 0
+			for _, node := range tree.Nodes {
+				if !node.OnSolution {
+					continue
+				}
+				onSolution++
+				if len(branch) == 0 || branch[len(branch)-1] != node.Node.Position {
+					branch = append(branch, node.Node.Position)
+				}
+			}
+			path := result.PathFound
+			if len(path) > 0 && path[0] == start {
+				path = path[1:]
+			}
+			expected := append([]datatypes.BoardCoordinate{start}, path...)
+			if !reflect.DeepEqual(branch, expected) {
+				t.Errorf("solution branch %v, the path found is %v", branch, expected)
+			}
+			if restarts := onSolution - len(expected); restarts != test.restarts {
+				t.Errorf("%d nodes on the solution branch for a path of %d cells", onSolution, len(expected))
+			}
+		})
+	}
+}
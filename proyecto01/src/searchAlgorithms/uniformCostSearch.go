@@ -12,7 +12,12 @@ type UniformCostSearch struct{}
 func (a *UniformCostSearch) LookForGoal(e *enviroment) datatypes.SearchResult {
 	// Step 1: Find the path to the passenger
 	start := time.Now()
-	pathToPassenger, passengerExpandedNodes, passengerCost := a.findPath(e, 5)
+	// findPath moves the agent, the cost is counted from where it starts
+	origin := e.agent.position.CurrentPosition
+	pathToPassenger, passengerExpandedNodes := []datatypes.BoardCoordinate{origin}, 0
+	if !e.agent.passenger {
+		pathToPassenger, passengerExpandedNodes, _ = a.findPath(e, 5)
+	}
 	// If the path to the passenger is not found, return empty result
 	if len(pathToPassenger) == 0 {
 		return datatypes.SearchResult{
@@ -20,12 +25,12 @@ func (a *UniformCostSearch) LookForGoal(e *enviroment) datatypes.SearchResult {
 			SolutionFound: false,
 			ExpandenNodes: passengerExpandedNodes,
 			TreeDepth:     0,
-			Cost:          float32(passengerCost),
+			Cost:          0,
 			TimeExe:       time.Since(start),
 		}
 	}
 	// Step 2: Find the path from the passenger to the goal
-	pathToGoal, goalExpandedNodes, goalCost := a.findPath(e, 6)
+	pathToGoal, goalExpandedNodes, _ := a.findPath(e, 6)
 
 	// If the path to the goal is not found, return the path to the passenger
 	if len(pathToGoal) == 0 {
@@ -34,7 +39,7 @@ func (a *UniformCostSearch) LookForGoal(e *enviroment) datatypes.SearchResult {
 			SolutionFound: false,
 			ExpandenNodes: goalExpandedNodes, // You might want to count these nodes as well
 			TreeDepth:     len(pathToPassenger),
-			Cost:          float32(goalCost),
+			Cost:          PathCost(e.board, origin, pathToPassenger),
 			TimeExe:       time.Since(start),
 		}
 	}
@@ -47,7 +52,7 @@ func (a *UniformCostSearch) LookForGoal(e *enviroment) datatypes.SearchResult {
 		SolutionFound: true,
 		ExpandenNodes: goalExpandedNodes + passengerExpandedNodes, // You might want to count these nodes as well
 		TreeDepth:     len(combinedPath),
-		Cost:          float32(goalCost + passengerCost),
+		Cost:          PathCost(e.board, origin, combinedPath),
 		TimeExe:       time.Since(start),
 	}
 }
@@ -58,59 +63,85 @@ func (a *UniformCostSearch) findPath(e *enviroment, goal int) ([]datatypes.Board
 	expandenNodes := 0
 	priorityQueue := datatypes.PriorityQueue[datatypes.AgentStep]{}
 
+	// The second leg of the search is the one carrying the passenger
+	hasPassenger := goal == GOAL
+
 	// Start with the initial position and cost 0
 	initialCost := float32(0)
-	priorityQueue.Push(datatypes.Element[datatypes.AgentStep]{
-		Value: datatypes.AgentStep{
-			Depth:  e.agent.position.Depth,
-			Action: e.agent.position.Action,
-			Cost:   int(initialCost),
-			PreviousPosition: datatypes.BoardCoordinate{
-				X: math.MaxInt,
-				Y: math.MaxInt,
-			},
-			CurrentPosition: e.agent.position.CurrentPosition,
+	initialStep := datatypes.AgentStep{
+		Depth:  e.agent.position.Depth,
+		Action: e.agent.position.Action,
+		Cost:   int(initialCost),
+		PreviousPosition: datatypes.BoardCoordinate{
+			X: math.MaxInt,
+			Y: math.MaxInt,
 		},
+		CurrentPosition: e.agent.position.CurrentPosition,
+		ID:              e.agent.position.ID,
+	}
+	// Unless the passenger waited at the start, the second leg starts over
+	// from the node where the first one found it, and hangs from it
+	restarted := hasPassenger && e.agent.position.ParentID != 0
+	if restarted {
+		initialStep.ID, initialStep.ParentID = e.newID(), e.agent.position.ID
+	}
+	priorityQueue.Push(datatypes.Element[datatypes.AgentStep]{
+		Value:    initialStep,
 		Priority: 0}, // Priority can be based on cost from start
 	)
+	if restarted {
+		// The first leg already reported its start
+		e.notify.generate(stepNode(initialStep, true))
+	}
 
 	visited := make(map[datatypes.BoardCoordinate]bool)
 
-	for !priorityQueue.IsEmpty() {
+	for !priorityQueue.IsEmpty() && !e.notify.stopped() {
 		currentStep, _ := priorityQueue.Pop()
 
 		// Check if the current position is the goal
-		if e.board[currentStep.CurrentPosition.X][currentStep.CurrentPosition.Y] == goal {
+		reached := e.board[currentStep.CurrentPosition.X][currentStep.CurrentPosition.Y] == goal
+		e.notify.goalTest(stepNode(currentStep, hasPassenger), reached && hasPassenger)
+		if reached {
 			e.agent.position = currentStep
 			return reconstructPath(parentNodes, currentStep), expandenNodes, float32(currentStep.Cost)
 		}
 
 		if visited[currentStep.CurrentPosition] {
+			e.notify.prune(stepNode(currentStep, hasPassenger))
 			continue
 		}
 
 		visited[currentStep.CurrentPosition] = true
 		parentNodes = append(parentNodes, currentStep)
 		expandenNodes++
+		e.explored = append(e.explored, currentStep.CurrentPosition)
+		e.notify.expand(stepNode(currentStep, hasPassenger))
 
 		e.agent.position = currentStep
 		agentPerception := Percept(e.agent, e.board)
 
 		for _, perception := range agentPerception {
+			movementCost := float32(currentStep.Cost) + getCellCost(e.board[perception.Coordinate.X][perception.Coordinate.Y])
+			nextStep := datatypes.AgentStep{
+				Depth:            currentStep.Depth + 1,
+				Action:           perception.Direction,
+				Cost:             int(movementCost), // Use the calculated movement cost
+				CurrentPosition:  perception.Coordinate,
+				PreviousPosition: currentStep.CurrentPosition,
+				ID:               e.newID(),
+				ParentID:         currentStep.ID,
+			}
 			if !visited[perception.Coordinate] {
-				movementCost := float32(currentStep.Cost) + getCellCost(e.board[perception.Coordinate.X][perception.Coordinate.Y])
 				priorityQueue.Push(
 					datatypes.Element[datatypes.AgentStep]{
-						Value: datatypes.AgentStep{
-							Depth:            currentStep.Depth + 1,
-							Action:           perception.Direction,
-							Cost:             int(movementCost), // Use the calculated movement cost
-							CurrentPosition:  perception.Coordinate,
-							PreviousPosition: currentStep.CurrentPosition,
-						},
+						Value:    nextStep,
 						Priority: int(movementCost), // Priority is based on the total cost to reach this node
 					},
 				)
+				e.notify.generate(stepNode(nextStep, hasPassenger))
+			} else {
+				e.notify.prune(stepNode(nextStep, hasPassenger))
 			}
 		}
 	}
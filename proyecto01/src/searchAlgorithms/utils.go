@@ -1,69 +1,10 @@
 package searchAlgorithms
 
 import (
-	"bufio"
-	"fmt"
-	"os"
-	"strconv"
-	"strings"
-
 	"github.com/Krud3/InteligenciaArtificial/src/datatypes"
+	"github.com/Krud3/InteligenciaArtificial/src/i18n"
 )
 
-func coordinateType(x int, y int, coordinateValue int, coordinateMap map[string]datatypes.BoardCoordinate) {
-	if coordinateValue == 6 {
-		coordinateMap["goal"] = datatypes.BoardCoordinate{X: x, Y: y}
-	} else if coordinateValue == 5 {
-		coordinateMap["passenger"] = datatypes.BoardCoordinate{X: x, Y: y}
-	} else if coordinateValue == 2 {
-		coordinateMap["init"] = datatypes.BoardCoordinate{X: x, Y: y}
-	}
-}
-
-func GetMatrix(path string) (datatypes.ScannedMatrix, error) {
-	// Open the file
-	filePath := path //"./search/battery/Prueba1.txt"
-	file, err := os.Open(filePath)
-	if err != nil {
-		fmt.Printf("Error opening file: %s; error: %s", filePath, err)
-		var zero datatypes.ScannedMatrix
-		return zero, err
-	}
-	defer file.Close()
-
-	// Create a 2D slice to store the matrix
-	var matrix datatypes.Matrix
-
-	// Read the file line by line
-	scanner := bufio.NewScanner(file)
-
-	//For hold importants coordinates
-	mainCoordinates := make(map[string]datatypes.BoardCoordinate)
-
-	for lineIndex := 0; scanner.Scan(); lineIndex++ {
-		line := scanner.Text()
-		// Split the line by spaces to get individual string numbers
-		stringValues := strings.Fields(line)
-
-		// Convert string numbers to integers and append to a row
-		var row []int
-		for valueIndex, stringValue := range stringValues {
-			num, err := strconv.Atoi(stringValue)
-			if err != nil {
-				fmt.Println("Error converting to integer:", err)
-				var zero datatypes.ScannedMatrix
-				return zero, err
-			}
-			coordinateType(lineIndex, valueIndex, num, mainCoordinates)
-			row = append(row, num)
-		}
-
-		// Append the row to the matrix
-		matrix = append(matrix, row)
-	}
-	return datatypes.ScannedMatrix{Matrix: matrix, MainCoordinates: mainCoordinates}, nil
-}
-
 func FromPosToPath(path []Position) [][]int {
 	result := make([][]int, len(path))
 	for i, pos := range path {
@@ -72,20 +13,19 @@ func FromPosToPath(path []Position) [][]int {
 	return result
 }
 
-func ValidateMatrix(matrix [][]int) ([10][10]int, error) {
-	var result [10][10]int
-
-	if len(matrix) != 10 {
-		return result, fmt.Errorf("La matriz debe tener 10 filas, pero tiene %d", len(matrix))
+// ValidateMatrix comprueba que la matriz sea rectangular y devuelve una copia,
+// el tamaño puede ser cualquiera
+func ValidateMatrix(matrix [][]int) ([][]int, error) {
+	if len(matrix) == 0 || len(matrix[0]) == 0 {
+		return nil, i18n.Errorf("error.emptyMatrix")
 	}
 
-	for i := 0; i < 10; i++ {
-		if len(matrix[i]) != 10 {
-			return result, fmt.Errorf("La fila %d debe tener 10 columnas, pero tiene %d", i, len(matrix[i]))
-		}
-		for j := 0; j < 10; j++ {
-			result[i][j] = matrix[i][j]
+	result := make([][]int, len(matrix))
+	for i := range matrix {
+		if len(matrix[i]) != len(matrix[0]) {
+			return nil, i18n.Errorf("error.rowColumns", i, len(matrix[0]), len(matrix[i]))
 		}
+		result[i] = append([]int(nil), matrix[i]...)
 	}
 
 	return result, nil
@@ -139,7 +79,7 @@ func getSuccessors(node *Node, env *Environment, useGCost bool) []*Node {
 		newX := node.Position.X + dir.dx
 		newY := node.Position.Y + dir.dy
 
-		if newX >= 0 && newX < 10 && newY >= 0 && newY < 10 {
+		if newX >= 0 && newX < len(env.Matrix) && newY >= 0 && newY < len(env.Matrix[newX]) {
 			cellValue := env.Matrix[newX][newY]
 			if cellValue != WALL {
 				cost := getCellCost(cellValue)
@@ -154,6 +94,7 @@ func getSuccessors(node *Node, env *Environment, useGCost bool) []*Node {
 					Parent:               node,
 					Depth:                node.Depth + 1,
 					HasPickedUpPassenger: hasPickedUpPassenger,
+					ID:                   env.newID(),
 				}
 				newNode.H = heuristic(newNode, env)
 				newNode.G = node.G + cost
@@ -170,6 +111,31 @@ func getSuccessors(node *Node, env *Environment, useGCost bool) []*Node {
 	return successors
 }
 
+// CellCost is what it costs to drive into a cell with the given value
+func CellCost(cellValue int) float32 {
+	return getCellCost(cellValue)
+}
+
+// PathCost is what it costs to drive a path from start, every algorithm
+// reports it: entering a cell costs CellCost and the start is free. The path
+// can begin with the start cell or with the first move
+func PathCost(board [][]int, start datatypes.BoardCoordinate, path []datatypes.BoardCoordinate) float32 {
+	if len(path) > 0 && path[0] == start {
+		path = path[1:]
+	}
+	var cost float32
+	for _, cell := range path {
+		cost += getCellCost(board[cell.X][cell.Y])
+	}
+	return cost
+}
+
+// Moves returns the cells reachable in one move from position, following the
+// same rules the algorithms use through Percept
+func Moves(board [][]int, position datatypes.BoardCoordinate) []datatypes.CoordinateMovement {
+	return Percept(agent{position: datatypes.AgentStep{CurrentPosition: position}}, board)
+}
+
 func getCellCost(cellValue int) float32 {
 	switch cellValue {
 	case 0: // Tráfico liviano
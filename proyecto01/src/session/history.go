@@ -0,0 +1,156 @@
+package session
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/Krud3/InteligenciaArtificial/src/datatypes"
+)
+
+const historyFile = "history.jsonl"
+
+// Where a run was started from
+const (
+	SourceGame    = "game"
+	SourceCompare = "compare"
+	SourceCLI     = "cli"
+)
+
+// Entry is one search of the history
+type Entry struct {
+	Time      time.Time     `json:"time"`
+	Source    string        `json:"source"`
+	Map       string        `json:"map"`
+	MapHash   string        `json:"map_hash"`
+	Algorithm string        `json:"algorithm"`
+	Status    string        `json:"status"`
+	Cost      float32       `json:"cost"`
+	Nodes     int           `json:"nodes"`
+	Depth     int           `json:"depth"`
+	Duration  time.Duration `json:"duration_ns"`
+}
+
+// NewEntry describes a finished search of a map
+func NewEntry(source, mapName string, matrix datatypes.Matrix, algorithm string, result datatypes.SearchResult) Entry {
+	return Entry{
+		Time:      time.Now(),
+		Source:    source,
+		Map:       filepath.Base(mapName),
+		MapHash:   MapHash(matrix),
+		Algorithm: algorithm,
+		Status:    result.Status.String(),
+		Cost:      result.Cost,
+		Nodes:     result.ExpandenNodes,
+		Depth:     result.TreeDepth,
+		Duration:  result.TimeExe,
+	}
+}
+
+// MapHash identifies the contents of a map, so runs of a map that was edited
+// under the same name can be told apart
+func MapHash(matrix datatypes.Matrix) string {
+	hash := sha256.New()
+	for _, row := range matrix {
+		for _, cell := range row {
+			fmt.Fprintf(hash, "%d ", cell)
+		}
+		hash.Write([]byte{'\n'})
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:12]
+}
+
+// Record adds an entry at the end of the history, one JSON object per line
+// so a run never rewrites the ones before it
+func Record(entry Entry) error {
+	if err := os.MkdirAll(Dir(), os.ModePerm); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(filepath.Join(Dir(), historyFile), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		file.Close()
+		return err
+	}
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// History returns every entry, oldest first. Lines that can't be read are
+// skipped, an empty history isn't an error
+func History() ([]Entry, error) {
+	file, err := os.Open(filepath.Join(Dir(), historyFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry Entry
+		if json.Unmarshal(scanner.Bytes(), &entry) == nil {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, scanner.Err()
+}
+
+// WriteCSV writes the entries with a header, times in RFC 3339 and durations
+// in milliseconds
+func WriteCSV(w io.Writer, entries []Entry) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"time", "source", "map", "map_hash", "algorithm", "status", "cost", "nodes", "depth", "time_ms"})
+	for _, entry := range entries {
+		writer.Write([]string{
+			entry.Time.Format(time.RFC3339),
+			entry.Source,
+			entry.Map,
+			entry.MapHash,
+			entry.Algorithm,
+			entry.Status,
+			strconv.FormatFloat(float64(entry.Cost), 'f', -1, 32),
+			strconv.Itoa(entry.Nodes),
+			strconv.Itoa(entry.Depth),
+			strconv.FormatFloat(float64(entry.Duration.Microseconds())/1000, 'f', 3, 64),
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ExportCSV writes the whole history to a CSV file
+func ExportCSV(path string) error {
+	entries, err := History()
+	if err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := WriteCSV(file, entries); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,23 @@
+// Package session keeps what the game remembers between launches: the
+// settings of the last session and the history of the searches run from the
+// game or the command line
+package session
+
+import "github.com/Krud3/InteligenciaArtificial/src/appdir"
+
+// DirEnv is the environment variable that sets the directory of the session files
+const DirEnv = "IA_SESSION_DIR"
+
+// Set with SetDir, it takes priority over the environment
+var dir string
+
+// SetDir changes the directory where the settings and the history are kept
+func SetDir(path string) {
+	dir = path
+}
+
+// Dir returns the directory of the session files: the one given to SetDir,
+// the one in DirEnv or the session directory of appdir, next to the maps
+func Dir() string {
+	return appdir.Dir(dir, DirEnv, appdir.Config("session"))
+}
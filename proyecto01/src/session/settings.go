@@ -0,0 +1,57 @@
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const settingsFile = "settings.json"
+
+// Settings is what the game restores when it starts. Empty fields keep the
+// defaults of the game
+type Settings struct {
+	Map          string `json:"map,omitempty"`
+	Algorithm    string `json:"algorithm,omitempty"`
+	Speed        int    `json:"speed"` // Index of the speed of the car, -1 for the default
+	Theme        string `json:"theme,omitempty"`
+	Language     string `json:"language,omitempty"` // Only when picked in the menu, otherwise the locale decides
+	WindowWidth  int    `json:"window_width,omitempty"`
+	WindowHeight int    `json:"window_height,omitempty"`
+}
+
+// DefaultSettings are the ones of a first launch
+func DefaultSettings() Settings {
+	return Settings{Speed: -1}
+}
+
+// LoadSettings reads the settings of the last session, a missing file gives
+// the default ones
+func LoadSettings() (Settings, error) {
+	settings := DefaultSettings()
+	data, err := os.ReadFile(filepath.Join(Dir(), settingsFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return settings, nil
+	}
+	if err != nil {
+		return settings, err
+	}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return DefaultSettings(), err
+	}
+	return settings, nil
+}
+
+// SaveSettings writes the settings, creating the session directory if needed
+func SaveSettings(settings Settings) error {
+	if err := os.MkdirAll(Dir(), os.ModePerm); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(Dir(), settingsFile), append(data, '\n'), 0o644)
+}
@@ -2,24 +2,11 @@ package utils
 
 import (
 	"image"
-	"image/png"
-	"log"
-	"os"
-)
-
-func LoadIcon(path string) image.Image {
-	// Open the icon file
-	file, err := os.Open(path)
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer file.Close()
 
-	// Decode the image
-	icon, err := png.Decode(file)
-	if err != nil {
-		log.Fatal(err)
-	}
+	"github.com/Krud3/InteligenciaArtificial/src/game/assets"
+)
 
-	return icon
+// LoadIcon returns one of the embedded images to use as the window icon
+func LoadIcon(name string) (image.Image, error) {
+	return assets.Decode(name)
 }
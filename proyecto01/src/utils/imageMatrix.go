@@ -0,0 +1,212 @@
+package utils
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Krud3/InteligenciaArtificial/src/datatypes"
+	"github.com/Krud3/InteligenciaArtificial/src/i18n"
+)
+
+// ColorPalette maps a pixel colour to the cell value used in the battery files
+type ColorPalette map[color.RGBA]int
+
+// DefaultPalette is the colour key used when no palette is given
+var DefaultPalette = ColorPalette{
+	{R: 255, G: 255, B: 255, A: 255}: 0, // Calle
+	{R: 0, G: 0, B: 0, A: 255}:       1, // Muro
+	{R: 0, G: 0, B: 255, A: 255}:     2, // Inicio
+	{R: 255, G: 255, B: 0, A: 255}:   3, // Tráfico medio
+	{R: 255, G: 128, B: 0, A: 255}:   4, // Tráfico pesado
+	{R: 0, G: 255, B: 0, A: 255}:     5, // Pasajero
+	{R: 255, G: 0, B: 0, A: 255}:     6, // Destino
+}
+
+// ImageImportOptions configures how an image is turned into a matrix
+type ImageImportOptions struct {
+	// Side in pixels of the square block that becomes one cell (1 = one pixel per cell)
+	BlockSize int
+	// Colour key, DefaultPalette if nil
+	Palette ColorPalette
+	// Maximum per-channel distance to accept a colour that is not exactly in the palette
+	Tolerance int
+}
+
+// ParsePalette reads a palette written as "rrggbb=value,rrggbb=value,..."
+func ParsePalette(spec string) (ColorPalette, error) {
+	palette := make(ColorPalette)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		hex, value, found := strings.Cut(entry, "=")
+		if !found {
+			return nil, i18n.Errorf("error.paletteEntry", entry)
+		}
+		hex = strings.TrimPrefix(strings.TrimSpace(hex), "#")
+		rgb, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil || len(hex) != 6 {
+			return nil, i18n.Errorf("error.paletteColour", hex)
+		}
+		cellValue, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return nil, i18n.Errorf("error.paletteValue", value)
+		}
+		palette[color.RGBA{R: uint8(rgb >> 16), G: uint8(rgb >> 8), B: uint8(rgb), A: 255}] = cellValue
+	}
+	if len(palette) == 0 {
+		return nil, i18n.Errorf("error.emptyPalette")
+	}
+	return palette, nil
+}
+
+// GetMatrixFromImage reads a PNG map where every block of pixels is a cell
+func GetMatrixFromImage(path string, options ImageImportOptions) (datatypes.ScannedMatrix, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return datatypes.ScannedMatrix{}, err
+	}
+	defer file.Close()
+
+	// The size is in the header, a huge image is refused before decoding it
+	config, err := png.DecodeConfig(file)
+	if err != nil {
+		return datatypes.ScannedMatrix{}, i18n.Errorf("error.decode", path, err)
+	}
+	if _, _, err := imageCells(config.Width, config.Height, options.BlockSize); err != nil {
+		return datatypes.ScannedMatrix{}, err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return datatypes.ScannedMatrix{}, err
+	}
+	img, err := png.Decode(file)
+	if err != nil {
+		return datatypes.ScannedMatrix{}, i18n.Errorf("error.decode", path, err)
+	}
+	return ScanImage(img, options)
+}
+
+// imageCells tells how many rows and columns of cells an image makes, within
+// the same limits of the map files
+func imageCells(width, height, blockSize int) (int, int, error) {
+	if blockSize <= 0 {
+		blockSize = 1
+	}
+	if width%blockSize != 0 || height%blockSize != 0 {
+		return 0, 0, i18n.Errorf("error.blockSize", width, height, blockSize)
+	}
+	rows, columns := height/blockSize, width/blockSize
+	if rows > DefaultMaxRows || columns > DefaultMaxColumns {
+		return 0, 0, i18n.Errorf("error.imageTooBig", columns, rows, DefaultMaxColumns, DefaultMaxRows)
+	}
+	return rows, columns, nil
+}
+
+// ScanImage converts an image into a matrix using the colour key of the
+// options. The map has to be playable, checked with CheckMap
+func ScanImage(img image.Image, options ImageImportOptions) (datatypes.ScannedMatrix, error) {
+	blockSize := options.BlockSize
+	if blockSize <= 0 {
+		blockSize = 1
+	}
+	palette := options.Palette
+	if palette == nil {
+		palette = DefaultPalette
+	}
+
+	bounds := img.Bounds()
+	rows, columns, err := imageCells(bounds.Dx(), bounds.Dy(), blockSize)
+	if err != nil {
+		return datatypes.ScannedMatrix{}, err
+	}
+
+	matrix := make(datatypes.Matrix, rows)
+	mainCoordinates := make(map[string]datatypes.BoardCoordinate)
+	for row := 0; row < rows; row++ {
+		matrix[row] = make([]int, columns)
+		for column := 0; column < columns; column++ {
+			block := image.Rect(
+				bounds.Min.X+column*blockSize, bounds.Min.Y+row*blockSize,
+				bounds.Min.X+(column+1)*blockSize, bounds.Min.Y+(row+1)*blockSize,
+			)
+			blockColor := dominantColor(img, block)
+			value, ok := palette.lookup(blockColor, options.Tolerance)
+			if !ok {
+				return datatypes.ScannedMatrix{}, i18n.Errorf("error.colourNotInPalette", blockColor.R, blockColor.G, blockColor.B, row, column)
+			}
+			coordinateType(row, column, value, mainCoordinates)
+			matrix[row][column] = value
+		}
+	}
+
+	// Repeated or missing special cells, palette values out of range and
+	// unreachable cells are refused like in the editor
+	if err := CheckMap(matrix); err != nil {
+		return datatypes.ScannedMatrix{}, err
+	}
+	return datatypes.ScannedMatrix{Matrix: matrix, MainCoordinates: mainCoordinates}, nil
+}
+
+// dominantColor returns the most repeated colour of a block, so anti-aliased edges don't matter
+func dominantColor(img image.Image, block image.Rectangle) color.RGBA {
+	counts := make(map[color.RGBA]int)
+	var best color.RGBA
+	for y := block.Min.Y; y < block.Max.Y; y++ {
+		for x := block.Min.X; x < block.Max.X; x++ {
+			c := color.RGBAModel.Convert(img.At(x, y)).(color.RGBA)
+			counts[c]++
+			if counts[c] > counts[best] {
+				best = c
+			}
+		}
+	}
+	return best
+}
+
+func (p ColorPalette) lookup(c color.RGBA, tolerance int) (int, bool) {
+	if value, found := p[c]; found {
+		return value, true
+	}
+	bestDistance := tolerance + 1
+	bestValue := 0
+	for key, value := range p {
+		distance := max(channelDistance(key.R, c.R), channelDistance(key.G, c.G), channelDistance(key.B, c.B))
+		if distance < bestDistance || (distance == bestDistance && value < bestValue) {
+			bestDistance = distance
+			bestValue = value
+		}
+	}
+	return bestValue, bestDistance <= tolerance
+}
+
+func channelDistance(a, b uint8) int {
+	if a > b {
+		return int(a - b)
+	}
+	return int(b - a)
+}
+
+// FormatMatrix writes a matrix with the same format of the battery files,
+// ParseMatrix reads it back as it was
+func FormatMatrix(matrix datatypes.Matrix) string {
+	lines := make([]string, len(matrix))
+	for i, row := range matrix {
+		values := make([]string, len(row))
+		for j, value := range row {
+			values[j] = strconv.Itoa(value)
+		}
+		lines[i] = strings.Join(values, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// SaveMatrix writes a matrix to a file with FormatMatrix
+func SaveMatrix(path string, matrix datatypes.Matrix) error {
+	return os.WriteFile(path, []byte(FormatMatrix(matrix)), 0644)
+}
@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"image"
+	"image/color"
+	"reflect"
+	"testing"
+
+	"github.com/Krud3/InteligenciaArtificial/src/datatypes"
+)
+
+// paint draws a matrix with the default palette, one pixel per cell
+func paint(matrix datatypes.Matrix, width int) *image.RGBA {
+	colours := make(map[int]color.RGBA)
+	for colour, value := range DefaultPalette {
+		colours[value] = colour
+	}
+	img := image.NewRGBA(image.Rect(0, 0, width, len(matrix)))
+	for i, row := range matrix {
+		for j := 0; j < width; j++ {
+			value := 0
+			if j < len(row) {
+				value = row[j]
+			}
+			img.SetRGBA(j, i, colours[value])
+		}
+	}
+	return img
+}
+
+func TestScanImage(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		matrix   datatypes.Matrix
+		width    int
+		accepted bool
+	}{
+		{"Playable", datatypes.Matrix{{2, 0, 5}, {1, 3, 6}}, 3, true},
+		{"RepeatedPassenger", datatypes.Matrix{{2, 5, 5}, {1, 0, 6}}, 3, false},
+		{"RepeatedGoal", datatypes.Matrix{{2, 0, 5}, {6, 0, 6}}, 3, false},
+		{"NoStart", datatypes.Matrix{{0, 0, 5}, {1, 0, 6}}, 3, false},
+		{"Unreachable", datatypes.Matrix{{2, 1, 5}, {1, 0, 6}}, 3, false},
+		{"TooWide", datatypes.Matrix{{2, 5, 6}}, DefaultMaxColumns + 1, false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			scanned, err := ScanImage(paint(test.matrix, test.width), ImageImportOptions{})
+			if (err == nil) != test.accepted {
+				t.Fatalf("error %v, accepted expected %v", err, test.accepted)
+			}
+			if err == nil && !reflect.DeepEqual(scanned.Matrix, test.matrix) {
+				t.Fatalf("scanned %v, expected %v", scanned.Matrix, test.matrix)
+			}
+		})
+	}
+}
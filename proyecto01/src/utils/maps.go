@@ -0,0 +1,109 @@
+package utils
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Krud3/InteligenciaArtificial/battery"
+	"github.com/Krud3/InteligenciaArtificial/src/appdir"
+	"github.com/Krud3/InteligenciaArtificial/src/datatypes"
+)
+
+// MapDirEnv is the environment variable that sets the directory of the user maps
+const MapDirEnv = "IA_MAPS_DIR"
+
+// Set with SetMapDir, it takes priority over the environment
+var mapDir string
+
+// SetMapDir changes the directory where maps are listed, read and saved
+func SetMapDir(dir string) {
+	mapDir = dir
+}
+
+// MapDir returns the directory of the user maps: the one given to SetMapDir,
+// the one in MapDirEnv or the default one, in that order
+func MapDir() string {
+	return appdir.Dir(mapDir, MapDirEnv, DefaultMapDir())
+}
+
+// DefaultMapDir is the battery of the repository when the game is started from
+// src, like it always was, and the maps directory of appdir otherwise
+func DefaultMapDir() string {
+	if info, err := os.Stat("../battery"); err == nil && info.IsDir() {
+		return "../battery"
+	}
+	return appdir.Config("maps")
+}
+
+// openMap opens a map of the map directory or, if it isn't there, one of the
+// embedded maps. A name with directories is opened as a path
+func openMap(name string) (io.ReadCloser, error) {
+	if filepath.Base(name) != name {
+		return os.Open(name)
+	}
+	file, err := os.Open(filepath.Join(MapDir(), name))
+	if err == nil || !errors.Is(err, fs.ErrNotExist) {
+		return file, err
+	}
+	embedded, embeddedErr := battery.Maps.Open(name)
+	if embeddedErr != nil {
+		// The error of the map directory says where the map was looked for
+		return nil, err
+	}
+	return embedded, nil
+}
+
+// MapExists tells if a map name is taken, in the map directory or embedded
+func MapExists(name string) bool {
+	file, err := openMap(name)
+	if err != nil {
+		return false
+	}
+	file.Close()
+	return true
+}
+
+// ListMaps returns the names of the embedded maps and the ones in the map
+// directory, sorted. A missing map directory isn't an error
+func ListMaps() ([]string, error) {
+	names := make(map[string]bool)
+	embedded, err := fs.Glob(battery.Maps, "*.txt")
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range embedded {
+		names[name] = true
+	}
+
+	entries, err := os.ReadDir(MapDir())
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.EqualFold(filepath.Ext(entry.Name()), ".txt") {
+			names[entry.Name()] = true
+		}
+	}
+
+	list := make([]string, 0, len(names))
+	for name := range names {
+		list = append(list, name)
+	}
+	sort.Strings(list)
+	return list, nil
+}
+
+// SaveMap writes a map into the map directory, creating it if needed, and
+// returns the path of the file
+func SaveMap(name string, matrix datatypes.Matrix) (string, error) {
+	if err := os.MkdirAll(MapDir(), os.ModePerm); err != nil {
+		return "", err
+	}
+	path := filepath.Join(MapDir(), name)
+	return path, SaveMatrix(path, matrix)
+}
@@ -1,12 +1,11 @@
 package utils
 
 import (
-	"bufio"
 	"fmt"
+	"io"
+
 	"github.com/Krud3/InteligenciaArtificial/src/datatypes"
-	"os"
-	"strconv"
-	"strings"
+	"github.com/Krud3/InteligenciaArtificial/src/i18n"
 )
 
 func coordinateType(x int, y int, coordinateValue int, coordinateMap map[string]datatypes.BoardCoordinate) {
@@ -19,46 +18,80 @@ func coordinateType(x int, y int, coordinateValue int, coordinateMap map[string]
 	}
 }
 
-func GetMatrix(path string) (datatypes.ScannedMatrix, error) {
-	// Open the file
-	filePath := ("../battery/" + path) //"./search/battery/Prueba1.txt"
-	file, err := os.Open(filePath)
+// GetMatrix reads a map by name from the map directory, falling back to the
+// maps embedded in the binary. A name with directories is read as a path
+func GetMatrix(name string) (datatypes.ScannedMatrix, error) {
+	file, err := openMap(name)
 	if err != nil {
-		fmt.Printf("Error opening file: %s; error: %s", filePath, err)
+		fmt.Print(i18n.T("log.openFile", name, err))
 		var zero datatypes.ScannedMatrix
 		return zero, err
 	}
 	defer file.Close()
+	return ParseMatrix(file, ParseOptions{Mode: parseMode})
+}
 
-	// Create a 2D slice to store the matrix
-	var matrix datatypes.Matrix
-
-	// Read the file line by line
-	scanner := bufio.NewScanner(file)
+// ReadMatrix reads a map written as rows of space separated cell values, strictly
+func ReadMatrix(reader io.Reader) (datatypes.ScannedMatrix, error) {
+	return ParseMatrix(reader, ParseOptions{})
+}
 
-	//For hold importants coordinates
+// CheckMap tells if a matrix is a playable map: rectangular, with known cell
+// values, one start, one passenger and one goal, and a route between them
+func CheckMap(matrix datatypes.Matrix) error {
+	if len(matrix) == 0 || len(matrix[0]) == 0 {
+		return i18n.Errorf("error.emptyMap")
+	}
+	counts := make(map[int]int)
 	mainCoordinates := make(map[string]datatypes.BoardCoordinate)
+	for i, row := range matrix {
+		if len(row) != len(matrix[0]) {
+			return i18n.Errorf("error.rowLength", i, len(row), len(matrix[0]))
+		}
+		for j, value := range row {
+			if value < 0 || value > 6 {
+				return i18n.Errorf("error.cellValue", value, i, j)
+			}
+			counts[value]++
+			coordinateType(i, j, value, mainCoordinates)
+		}
+	}
+	for _, required := range specialCells {
+		if counts[required.value] != 1 {
+			return i18n.Errorf("error.exactlyOne", i18n.T(required.name), counts[required.value])
+		}
+	}
 
-	for lineIndex := 0; scanner.Scan(); lineIndex++ {
-		line := scanner.Text()
-		// Split the line by spaces to get individual string numbers
-		stringValues := strings.Fields(line)
+	reachable := reachableCells(matrix, mainCoordinates["init"])
+	if !reachable[mainCoordinates["passenger"]] {
+		return i18n.Errorf("error.passengerUnreachable")
+	}
+	if !reachable[mainCoordinates["goal"]] {
+		return i18n.Errorf("error.goalUnreachable")
+	}
+	return nil
+}
 
-		// Convert string numbers to integers and append to a row
-		var row []int
-		for valueIndex, stringValue := range stringValues {
-			num, err := strconv.Atoi(stringValue)
-			if err != nil {
-				fmt.Println("Error converting to integer:", err)
-				var zero datatypes.ScannedMatrix
-				return zero, err
+// reachableCells returns every cell connected to the start without crossing walls
+func reachableCells(matrix datatypes.Matrix, start datatypes.BoardCoordinate) map[datatypes.BoardCoordinate]bool {
+	reachable := map[datatypes.BoardCoordinate]bool{start: true}
+	queue := []datatypes.BoardCoordinate{start}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, next := range []datatypes.BoardCoordinate{
+			{X: current.X - 1, Y: current.Y}, {X: current.X, Y: current.Y + 1},
+			{X: current.X + 1, Y: current.Y}, {X: current.X, Y: current.Y - 1},
+		} {
+			if next.X < 0 || next.Y < 0 || next.X >= len(matrix) || next.Y >= len(matrix[next.X]) {
+				continue
 			}
-			coordinateType(lineIndex, valueIndex, num, mainCoordinates)
-			row = append(row, num)
+			if matrix[next.X][next.Y] == 1 || reachable[next] {
+				continue
+			}
+			reachable[next] = true
+			queue = append(queue, next)
 		}
-
-		// Append the row to the matrix
-		matrix = append(matrix, row)
 	}
-	return datatypes.ScannedMatrix{Matrix: matrix, MainCoordinates: mainCoordinates}, nil
+	return reachable
 }
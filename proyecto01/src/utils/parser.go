@@ -0,0 +1,183 @@
+package utils
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/Krud3/InteligenciaArtificial/src/datatypes"
+	"github.com/Krud3/InteligenciaArtificial/src/i18n"
+)
+
+// ParseMode tells what the parser does with a map that isn't well formed
+type ParseMode int
+
+const (
+	// Strict refuses anything but rows of the same length with known cell values
+	Strict ParseMode = iota
+	// Lenient fixes what it can: it skips blank lines and # comments, takes
+	// commas and semicolons as separators, fills short rows and unknown
+	// values with walls and turns repeated special cells into road
+	Lenient
+)
+
+// Limits of a map when ParseOptions leaves them at zero, the biggest board
+// the game can draw
+const (
+	DefaultMaxRows    = 120
+	DefaultMaxColumns = 120
+	DefaultMaxBytes   = 1 << 20
+)
+
+type ParseOptions struct {
+	Mode       ParseMode
+	MaxRows    int
+	MaxColumns int
+	MaxBytes   int
+}
+
+// Set with SetParseMode, used by GetMatrix
+var parseMode = Strict
+
+// SetParseMode changes how GetMatrix reads the maps
+func SetParseMode(mode ParseMode) {
+	parseMode = mode
+}
+
+// Cells a map needs exactly one of, with their name in the errors
+var specialCells = []struct {
+	value int
+	name  string
+}{{2, "cell.start"}, {5, "cell.passenger"}, {6, "cell.goal"}}
+
+func isSpecial(value int) bool {
+	return value == 2 || value == 5 || value == 6
+}
+
+// ParseMatrix reads a map written as rows of space separated cell values.
+// The map it returns is rectangular, within the limits, with cell values
+// from 0 to 6 and exactly one start, passenger and goal. Whether there's a
+// route between them is checked by CheckMap
+func ParseMatrix(reader io.Reader, options ParseOptions) (datatypes.ScannedMatrix, error) {
+	var zero datatypes.ScannedMatrix
+	if options.MaxRows <= 0 {
+		options.MaxRows = DefaultMaxRows
+	}
+	if options.MaxColumns <= 0 {
+		options.MaxColumns = DefaultMaxColumns
+	}
+	if options.MaxBytes <= 0 {
+		options.MaxBytes = DefaultMaxBytes
+	}
+	lenient := options.Mode == Lenient
+
+	// One byte over the limit is enough to know the map is too big
+	limited := &io.LimitedReader{R: reader, N: int64(options.MaxBytes) + 1}
+	scanner := bufio.NewScanner(limited)
+	scanner.Buffer(make([]byte, 0, 4096), options.MaxBytes+1)
+
+	var matrix datatypes.Matrix
+	var lines []int // Line of the file of every row, for the errors
+	blankLines := 0
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		var fields []string
+		if lenient {
+			text, _, _ = strings.Cut(text, "#")
+			fields = strings.FieldsFunc(text, func(r rune) bool {
+				// The same spaces strings.Fields splits on, so a strict map reads the same
+				return unicode.IsSpace(r) || r == ',' || r == ';'
+			})
+		} else {
+			fields = strings.Fields(text)
+		}
+		if len(fields) == 0 {
+			// Blank lines are only fine at the end, the usual trailing newlines
+			blankLines++
+			continue
+		}
+		if blankLines > 0 && !lenient {
+			return zero, i18n.Errorf("error.blankLine", line-blankLines)
+		}
+		blankLines = 0
+
+		if len(matrix) == options.MaxRows {
+			return zero, i18n.Errorf("error.tooManyRows", options.MaxRows)
+		}
+		if len(fields) > options.MaxColumns {
+			return zero, i18n.Errorf("error.tooManyColumns", line, options.MaxColumns)
+		}
+		row := make([]int, len(fields))
+		for j, field := range fields {
+			value, err := strconv.Atoi(field)
+			if err != nil {
+				return zero, i18n.Errorf("error.notCellValue", line, field)
+			}
+			if value < 0 || value > 6 {
+				if !lenient {
+					return zero, i18n.Errorf("error.lineCellValue", line, value, j+1)
+				}
+				value = 1
+			}
+			row[j] = value
+		}
+		matrix = append(matrix, row)
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return zero, i18n.Errorf("error.mapTooBig", options.MaxBytes)
+		}
+		return zero, err
+	}
+	if limited.N == 0 {
+		return zero, i18n.Errorf("error.mapTooBig", options.MaxBytes)
+	}
+	if len(matrix) == 0 {
+		return zero, i18n.Errorf("error.emptyMap")
+	}
+
+	columns := len(matrix[0])
+	if lenient {
+		for _, row := range matrix {
+			columns = max(columns, len(row))
+		}
+	}
+	for i, row := range matrix {
+		if len(row) == columns {
+			continue
+		}
+		if !lenient {
+			return zero, i18n.Errorf("error.lineLength", lines[i], len(row), columns)
+		}
+		for len(matrix[i]) < columns {
+			matrix[i] = append(matrix[i], 1)
+		}
+	}
+
+	mainCoordinates := make(map[string]datatypes.BoardCoordinate)
+	counts := make(map[int]int)
+	for i, row := range matrix {
+		for j, value := range row {
+			if !isSpecial(value) {
+				continue
+			}
+			counts[value]++
+			if counts[value] == 1 {
+				coordinateType(i, j, value, mainCoordinates)
+			} else if lenient {
+				// The first one stays
+				matrix[i][j] = 0
+			}
+		}
+	}
+	for _, special := range specialCells {
+		if counts[special.value] == 0 || (counts[special.value] > 1 && !lenient) {
+			return zero, i18n.Errorf("error.exactlyOne", i18n.T(special.name), counts[special.value])
+		}
+	}
+	return datatypes.ScannedMatrix{Matrix: matrix, MainCoordinates: mainCoordinates}, nil
+}
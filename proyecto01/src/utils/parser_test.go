@@ -0,0 +1,225 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"io/fs"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Krud3/InteligenciaArtificial/battery"
+	"github.com/Krud3/InteligenciaArtificial/src/datatypes"
+	"github.com/Krud3/InteligenciaArtificial/src/i18n"
+	"github.com/Krud3/InteligenciaArtificial/src/searchAlgorithms"
+)
+
+// Small limits so the fuzzer spends its time on the shape of the maps
+var fuzzOptions = ParseOptions{MaxRows: 24, MaxColumns: 24, MaxBytes: 2048}
+
+var parserSeeds = []string{
+	"",
+	"2 5 6",
+	"2 5\n6",
+	"2 5 6\n\n0 0 0\n",
+	"\n2 5 6",
+	"2 5 6\r\n0 1 0\r\n",
+	"-1 2 5 6",
+	"2 5 6 7",
+	"2 2 5 6 6",
+	"2,5;6 # comment",
+	"# only a comment",
+	"99999999999999999999 2 5 6",
+	"2 5 x 6",
+	"2 0 0 0\n5\n6 0",
+}
+
+func addParserSeeds(f *testing.F) {
+	for _, seed := range parserSeeds {
+		f.Add([]byte(seed))
+	}
+	names, err := fs.Glob(battery.Maps, "*.txt")
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, name := range names {
+		content, err := fs.ReadFile(battery.Maps, name)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(content)
+	}
+}
+
+// checkParsed checks the promises of ParseMatrix on a map it accepted
+func checkParsed(t *testing.T, scanned datatypes.ScannedMatrix, options ParseOptions) {
+	t.Helper()
+	matrix := scanned.Matrix
+	if len(matrix) == 0 || len(matrix) > options.MaxRows {
+		t.Fatalf("%d rows, the limit is %d", len(matrix), options.MaxRows)
+	}
+	columns := len(matrix[0])
+	if columns == 0 || columns > options.MaxColumns {
+		t.Fatalf("%d columns, the limit is %d", columns, options.MaxColumns)
+	}
+	found := make(map[string]datatypes.BoardCoordinate)
+	for i, row := range matrix {
+		if len(row) != columns {
+			t.Fatalf("row %d has %d columns, the first one %d", i, len(row), columns)
+		}
+		for j, value := range row {
+			if value < 0 || value > 6 {
+				t.Fatalf("cell value %d at (%d, %d)", value, i, j)
+			}
+			for _, special := range []struct {
+				value int
+				key   string
+			}{{2, "init"}, {5, "passenger"}, {6, "goal"}} {
+				if value != special.value {
+					continue
+				}
+				if _, repeated := found[special.key]; repeated {
+					t.Fatalf("more than one %s", special.key)
+				}
+				found[special.key] = datatypes.BoardCoordinate{X: i, Y: j}
+			}
+		}
+	}
+	if !reflect.DeepEqual(found, scanned.MainCoordinates) {
+		t.Fatalf("main coordinates %v, the cells say %v", scanned.MainCoordinates, found)
+	}
+}
+
+// FuzzParseMatrix checks the parser never panics and everything it accepts
+// is a well formed map, in both modes
+func FuzzParseMatrix(f *testing.F) {
+	addParserSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		strictOptions, lenientOptions := fuzzOptions, fuzzOptions
+		lenientOptions.Mode = Lenient
+
+		strict, strictErr := ParseMatrix(bytes.NewReader(data), strictOptions)
+		lenient, lenientErr := ParseMatrix(bytes.NewReader(data), lenientOptions)
+		if lenientErr == nil {
+			checkParsed(t, lenient, lenientOptions)
+		}
+		if strictErr != nil {
+			return
+		}
+		checkParsed(t, strict, strictOptions)
+		// The lenient mode reads a well formed map the same way
+		if lenientErr != nil {
+			t.Fatalf("the lenient mode refuses a strict map: %v", lenientErr)
+		}
+		if !reflect.DeepEqual(strict, lenient) {
+			t.Fatalf("the modes read different maps: %v and %v", strict.Matrix, lenient.Matrix)
+		}
+		// Written back like the battery it reads the same
+		again, err := ParseMatrix(strings.NewReader(FormatMatrix(strict.Matrix)), strictOptions)
+		if err != nil || !reflect.DeepEqual(again, strict) {
+			t.Fatalf("the map doesn't read the same after writing it: %v", err)
+		}
+	})
+}
+
+// FuzzParsedMapSearch checks the searches don't panic on the maps the
+// parser accepts, even the ones without a route
+func FuzzParsedMapSearch(f *testing.F) {
+	addParserSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		options := fuzzOptions
+		options.Mode = Lenient
+		matrix, err := ParseMatrix(bytes.NewReader(data), options)
+		if err != nil {
+			return
+		}
+		for _, name := range searchAlgorithms.AlgorithmNames {
+			_, err := searchAlgorithms.RunLimited(context.Background(), name, matrix, nil, searchAlgorithms.Limits{MaxExpansions: 5000})
+			if err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+		}
+	})
+}
+
+func TestParseMatrix(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		text     string
+		strict   bool
+		lenient  bool
+		expected datatypes.Matrix
+	}{
+		{"WellFormed", "2 0 5\n1 0 6\n", true, true, datatypes.Matrix{{2, 0, 5}, {1, 0, 6}}},
+		{"CarriageReturns", "2 0 5\r\n1 0 6\r\n", true, true, datatypes.Matrix{{2, 0, 5}, {1, 0, 6}}},
+		{"OtherSpaces", "2\v0\f5\n1\u00a00\u00856", true, true, datatypes.Matrix{{2, 0, 5}, {1, 0, 6}}},
+		{"Ragged", "2 0 5\n1 6\n", false, true, datatypes.Matrix{{2, 0, 5}, {1, 6, 1}}},
+		{"Negative", "2 -3 5\n1 0 6", false, true, datatypes.Matrix{{2, 1, 5}, {1, 0, 6}}},
+		{"UnknownCode", "2 9 5\n1 0 6", false, true, datatypes.Matrix{{2, 1, 5}, {1, 0, 6}}},
+		{"RepeatedStart", "2 2 5\n1 0 6", false, true, datatypes.Matrix{{2, 0, 5}, {1, 0, 6}}},
+		{"Comments", "# map\n2,0,5 # first row\n\n1;0;6", false, true, datatypes.Matrix{{2, 0, 5}, {1, 0, 6}}},
+		{"BlankLineInside", "2 0 5\n\n1 0 6", false, true, datatypes.Matrix{{2, 0, 5}, {1, 0, 6}}},
+		{"NoGoal", "2 0 5", false, false, nil},
+		{"NotANumber", "2 a 5\n1 0 6", false, false, nil},
+		{"Empty", "\n\n", false, false, nil},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			for _, mode := range []struct {
+				mode     ParseMode
+				accepted bool
+			}{{Strict, test.strict}, {Lenient, test.lenient}} {
+				matrix, err := ParseMatrix(strings.NewReader(test.text), ParseOptions{Mode: mode.mode})
+				if (err == nil) != mode.accepted {
+					t.Fatalf("mode %d: error %v, accepted expected %v", mode.mode, err, mode.accepted)
+				}
+				if err == nil && !reflect.DeepEqual(matrix.Matrix, test.expected) {
+					t.Fatalf("mode %d: read %v, expected %v", mode.mode, matrix.Matrix, test.expected)
+				}
+			}
+		})
+	}
+}
+
+// The errors point to the line of the file, not to a cell of the matrix
+func TestParseMatrixErrorLines(t *testing.T) {
+	i18n.SetLanguage("en")
+	for _, test := range []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{"CellValue", "2 0 5\n1 0 6\n0 9 0", "line 3: unknown cell value 9 in column 2"},
+		{"RowLength", "2 0 5\n1 0 6\n0 0", "line 3 has 2 columns, expected 3"},
+		{"Columns", "2 0 5\n1 0 6\n0 0 0 0 0", "line 3 has more than 4 columns"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseMatrix(strings.NewReader(test.text), ParseOptions{MaxColumns: 4})
+			if err == nil || err.Error() != test.expected {
+				t.Fatalf("error %v, expected %q", err, test.expected)
+			}
+		})
+	}
+}
+
+func TestParseMatrixLimits(t *testing.T) {
+	row := strings.Repeat("0 ", 9) + "0\n"
+	for _, test := range []struct {
+		name    string
+		text    string
+		options ParseOptions
+	}{
+		{"Rows", "2 5 6\n" + strings.Repeat("0 0 0\n", 10), ParseOptions{MaxRows: 10}},
+		{"Columns", "2 5 6 " + row, ParseOptions{MaxColumns: 10}},
+		{"Bytes", "2 5 6\n" + strings.Repeat("0 0 0\n", 100), ParseOptions{MaxBytes: 100}},
+		{"LongLine", "2 5 6" + strings.Repeat(" 0", 100), ParseOptions{MaxBytes: 100}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			for _, mode := range []ParseMode{Strict, Lenient} {
+				test.options.Mode = mode
+				if _, err := ParseMatrix(strings.NewReader(test.text), test.options); err == nil {
+					t.Fatalf("mode %d: a map over the limits was accepted", mode)
+				}
+			}
+		})
+	}
+}
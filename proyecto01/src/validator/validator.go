@@ -0,0 +1,141 @@
+// Package validator checks a solution without trusting the algorithm that
+// found it: every move has to be legal, the passenger has to be picked up
+// before reaching the goal, and the cost is computed again the same way for
+// every algorithm
+package validator
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/Krud3/InteligenciaArtificial/src/datatypes"
+	"github.com/Krud3/InteligenciaArtificial/src/i18n"
+	"github.com/Krud3/InteligenciaArtificial/src/searchAlgorithms"
+)
+
+// costTolerance absorbs the rounding of the float32 costs
+const costTolerance = 1e-3
+
+// Report is the result of checking a path
+type Report struct {
+	// Cost of the path: the cost of every cell entered, the start is free
+	Cost  float32
+	Moves int
+	// Moves done when the passenger was picked up, -1 if never
+	PickedUpAt int
+	// Everything wrong with the path, empty if it's a valid solution
+	Problems []string
+}
+
+func (r Report) Valid() bool {
+	return len(r.Problems) == 0
+}
+
+// CostMatches tells if a cost reported by an algorithm is the cost of the path
+func (r Report) CostMatches(reported float32) bool {
+	return math.Abs(float64(r.Cost-reported)) < costTolerance
+}
+
+func (r *Report) fail(key string, args ...any) {
+	r.Problems = append(r.Problems, i18n.T(key, args...))
+}
+
+// Check walks the path over the map from the start. The path can begin with
+// the start cell or with the first move, the algorithms return both
+func Check(matrix datatypes.ScannedMatrix, path []datatypes.BoardCoordinate) Report {
+	report := Report{PickedUpAt: -1}
+	start, hasStart := matrix.MainCoordinates["init"]
+	passenger, hasPassenger := matrix.MainCoordinates["passenger"]
+	goal, hasGoal := matrix.MainCoordinates["goal"]
+	if !hasStart || !hasPassenger || !hasGoal {
+		report.fail("validator.noMainCells")
+		return report
+	}
+	if len(path) > 0 && path[0] == start {
+		path = path[1:]
+	}
+	if len(path) == 0 {
+		report.fail("validator.empty")
+		return report
+	}
+
+	// A passenger waiting at the start gets in before the first move
+	if passenger == start {
+		report.PickedUpAt = 0
+	}
+	position := start
+	for i, cell := range path {
+		move := i + 1
+		if cell.X < 0 || cell.X >= len(matrix.Matrix) || cell.Y < 0 || cell.Y >= len(matrix.Matrix[cell.X]) {
+			report.fail("validator.outside", move, cell.X, cell.Y)
+			return report
+		}
+		if distance := abs(cell.X-position.X) + abs(cell.Y-position.Y); distance != 1 {
+			report.fail("validator.notAdjacent", move, position.X, position.Y, cell.X, cell.Y)
+		}
+		value := matrix.Matrix[cell.X][cell.Y]
+		if value == searchAlgorithms.WALL {
+			report.fail("validator.wall", move, cell.X, cell.Y)
+		}
+		report.Cost += searchAlgorithms.CellCost(value)
+		if cell == passenger && report.PickedUpAt < 0 {
+			report.PickedUpAt = move
+		}
+		position = cell
+	}
+	report.Moves = len(path)
+
+	if report.PickedUpAt < 0 {
+		report.fail("validator.noPassenger")
+	}
+	if position != goal {
+		report.fail("validator.notAtGoal", position.X, position.Y)
+	}
+	return report
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// ReadPath reads a path saved by WritePath: one cell per line, its row and
+// its column separated by spaces or a comma. Empty lines and lines starting
+// with # are skipped
+func ReadPath(r io.Reader) ([]datatypes.BoardCoordinate, error) {
+	var path []datatypes.BoardCoordinate
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })
+		if len(fields) != 2 {
+			return nil, i18n.Errorf("validator.badLine", line, text)
+		}
+		row, rowErr := strconv.Atoi(fields[0])
+		column, columnErr := strconv.Atoi(fields[1])
+		if rowErr != nil || columnErr != nil {
+			return nil, i18n.Errorf("validator.badLine", line, text)
+		}
+		path = append(path, datatypes.BoardCoordinate{X: row, Y: column})
+	}
+	return path, scanner.Err()
+}
+
+// WritePath writes a path, one "row column" per line
+func WritePath(w io.Writer, path []datatypes.BoardCoordinate) error {
+	for _, cell := range path {
+		if _, err := fmt.Fprintf(w, "%d %d\n", cell.X, cell.Y); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package validator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Krud3/InteligenciaArtificial/src/datatypes"
+	"github.com/Krud3/InteligenciaArtificial/src/i18n"
+)
+
+// 2 0 5
+// 1 3 1
+// 0 0 6
+var checkMap = datatypes.ScannedMatrix{
+	Matrix: datatypes.Matrix{{2, 0, 5}, {1, 3, 1}, {0, 0, 6}},
+	MainCoordinates: map[string]datatypes.BoardCoordinate{
+		"init":      {X: 0, Y: 0},
+		"passenger": {X: 0, Y: 2},
+		"goal":      {X: 2, Y: 2},
+	},
+}
+
+// The map files can't put the passenger on the start, only the coordinates can
+var startOnPassengerMap = datatypes.ScannedMatrix{
+	Matrix: datatypes.Matrix{{2, 0, 6}},
+	MainCoordinates: map[string]datatypes.BoardCoordinate{
+		"init":      {X: 0, Y: 0},
+		"passenger": {X: 0, Y: 0},
+		"goal":      {X: 0, Y: 2},
+	},
+}
+
+func cells(coordinates ...int) []datatypes.BoardCoordinate {
+	path := make([]datatypes.BoardCoordinate, 0, len(coordinates)/2)
+	for i := 0; i+1 < len(coordinates); i += 2 {
+		path = append(path, datatypes.BoardCoordinate{X: coordinates[i], Y: coordinates[i+1]})
+	}
+	return path
+}
+
+func problem(key string, args ...any) string {
+	return i18n.T(key, args...)
+}
+
+func TestCheck(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		matrix     datatypes.ScannedMatrix
+		path       []datatypes.BoardCoordinate
+		cost       float32
+		pickedUpAt int
+		problems   []string
+	}{
+		{"Valid", checkMap, cells(0, 1, 0, 2, 0, 1, 1, 1, 2, 1, 2, 2), 9, 2, nil},
+		{"ValidFromStart", checkMap, cells(0, 0, 0, 1, 0, 2, 0, 1, 1, 1, 2, 1, 2, 2), 9, 2, nil},
+		{"NotAdjacent", checkMap, cells(0, 2, 0, 1, 1, 1, 2, 1, 2, 2), 8, 1,
+			[]string{problem("validator.notAdjacent", 1, 0, 0, 0, 2)}},
+		{"Wall", checkMap, cells(0, 1, 0, 2, 1, 2, 2, 2), 4, 2,
+			[]string{problem("validator.wall", 3, 1, 2)}},
+		{"Outside", checkMap, cells(0, 1, -1, 1), 1, -1,
+			[]string{problem("validator.outside", 2, -1, 1)}},
+		{"NoPassenger", checkMap, cells(0, 1, 1, 1, 2, 1, 2, 2), 7, -1,
+			[]string{problem("validator.noPassenger")}},
+		{"NotAtGoal", checkMap, cells(0, 1, 0, 2), 2, 2,
+			[]string{problem("validator.notAtGoal", 0, 2)}},
+		{"Empty", checkMap, cells(0, 0), 0, -1, []string{problem("validator.empty")}},
+		{"StartOnPassenger", startOnPassengerMap, cells(0, 1, 0, 2), 2, 0, nil},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			report := Check(test.matrix, test.path)
+			if !reflect.DeepEqual(report.Problems, test.problems) {
+				t.Fatalf("problems %q, expected %q", report.Problems, test.problems)
+			}
+			if report.Valid() != (test.problems == nil) {
+				t.Fatalf("valid %v with problems %q", report.Valid(), report.Problems)
+			}
+			if !report.CostMatches(test.cost) {
+				t.Fatalf("cost %v, expected %v", report.Cost, test.cost)
+			}
+			if report.PickedUpAt != test.pickedUpAt {
+				t.Fatalf("picked up at %d, expected %d", report.PickedUpAt, test.pickedUpAt)
+			}
+		})
+	}
+}
+
+func TestReadPath(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		text     string
+		expected []datatypes.BoardCoordinate
+		badLine  int
+	}{
+		{"Written", "0 1\n0 2\n1 2\n", cells(0, 1, 0, 2, 1, 2), 0},
+		{"Comments", "# BFS\n\n0 1\n  # halfway\n0 2\n", cells(0, 1, 0, 2), 0},
+		{"Commas", "0,1\n0, 2\n1\t2\n", cells(0, 1, 0, 2, 1, 2), 0},
+		{"ThreeValues", "0 1\n0 2 3\n", nil, 2},
+		{"NotANumber", "0 1\n\nx 2\n", nil, 3},
+		{"OneValue", "4\n", nil, 1},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			path, err := ReadPath(strings.NewReader(test.text))
+			if test.badLine > 0 {
+				line := strings.Split(test.text, "\n")[test.badLine-1]
+				expected := i18n.Errorf("validator.badLine", test.badLine, strings.TrimSpace(line))
+				if err == nil || err.Error() != expected.Error() {
+					t.Fatalf("error %v, expected %v", err, expected)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(path, test.expected) {
+				t.Fatalf("read %v, expected %v", path, test.expected)
+			}
+		})
+	}
+}
+
+func TestWritePathReadsBack(t *testing.T) {
+	path := cells(0, 1, 0, 2, 1, 2)
+	var text strings.Builder
+	if err := WritePath(&text, path); err != nil {
+		t.Fatal(err)
+	}
+	again, err := ReadPath(strings.NewReader(text.String()))
+	if err != nil || !reflect.DeepEqual(again, path) {
+		t.Fatalf("read %v back, %v: %v", again, path, err)
+	}
+}
@@ -1 +1,35 @@
+// Package visuals holds what the screens of the game share, the widgets
+// themselves are in visuals/components
 package visuals
+
+import "image/color"
+
+// Colours of the buttons, the same ones the menu always had
+var (
+	Green  = color.RGBA{0, 160, 0, 255}
+	Blue   = color.RGBA{0, 50, 255, 255}
+	Orange = color.RGBA{235, 130, 0, 255}
+	Red    = color.RGBA{255, 0, 0, 255}
+	Grey   = color.RGBA{125, 125, 125, 255}
+	Navy   = color.RGBA{60, 60, 160, 255}
+)
+
+// Colours of the lists, tables and dropdowns
+var (
+	PanelColor     = color.RGBA{100, 100, 100, 255}
+	HeaderColor    = color.RGBA{70, 70, 70, 255}
+	HighlightColor = color.RGBA{0, 120, 0, 255}
+	HoverColor     = color.RGBA{130, 130, 130, 255}
+)
+
+// Darken returns c with every channel lowered by amount, for the inner part
+// of the buttons
+func Darken(c color.RGBA, amount uint8) color.RGBA {
+	darken := func(v uint8) uint8 {
+		if v < amount {
+			return 0
+		}
+		return v - amount
+	}
+	return color.RGBA{darken(c.R), darken(c.G), darken(c.B), c.A}
+}
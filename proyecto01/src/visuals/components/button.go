@@ -0,0 +1,115 @@
+package components
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/Krud3/InteligenciaArtificial/src/input"
+	"github.com/Krud3/InteligenciaArtificial/src/visuals"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+// Button is a coloured rectangle with a border and a centered label, which
+// can have several lines. It fires when the click is released over it, or
+// with Enter or Space while it has the focus
+type Button struct {
+	Rect    image.Rectangle
+	Label   string
+	Color   color.RGBA
+	OnClick func()
+	// Disabled buttons are drawn grey and ignore the clicks, nil is enabled
+	Disabled func() bool
+	pressed  bool // The click started over the button
+}
+
+func (b *Button) enabled() bool {
+	return b.Disabled == nil || !b.Disabled()
+}
+
+func (b *Button) Bounds() image.Rectangle {
+	return b.Rect
+}
+
+func (b *Button) CanFocus() bool {
+	return b.enabled()
+}
+
+func (b *Button) fire() {
+	if b.OnClick != nil {
+		b.OnClick()
+	}
+}
+
+func (b *Button) Update() bool {
+	if !b.enabled() {
+		b.pressed = false
+		return false
+	}
+	if input.Clicked(b.Rect) {
+		b.pressed = true
+		return true
+	}
+	if input.MouseReleased() && b.pressed {
+		b.pressed = false
+		// Releasing somewhere else cancels the click
+		if input.Hovered(b.Rect) {
+			b.fire()
+			return true
+		}
+	}
+	return false
+}
+
+func (b *Button) HandleKeys() bool {
+	if input.Pressed(ebiten.KeyEnter, ebiten.KeySpace) {
+		b.fire()
+		return true
+	}
+	return false
+}
+
+func (b *Button) Draw(screen *ebiten.Image) {
+	clr := b.Color
+	if !b.enabled() {
+		clr = visuals.Grey
+	}
+	fillRect(screen, b.Rect, clr)
+	inner := visuals.Darken(clr, 20)
+	if b.pressed {
+		inner = visuals.Darken(clr, 50)
+	}
+	fillRect(screen, b.Rect.Inset(5), inner)
+	printCentered(screen, b.Label, b.Rect)
+}
+
+// Label is a line of text, Text is read on every frame so it can be changed
+// at any time
+type Label struct {
+	Pos  image.Point
+	Text string
+}
+
+func (l *Label) Update() bool {
+	return false
+}
+
+func (l *Label) Draw(screen *ebiten.Image) {
+	ebitenutil.DebugPrintAt(screen, l.Text, l.Pos.X, l.Pos.Y)
+}
+
+// Image draws a picture at a fixed position, like the title of the menu
+type Image struct {
+	Pos   image.Point
+	Image *ebiten.Image
+}
+
+func (i *Image) Update() bool {
+	return false
+}
+
+func (i *Image) Draw(screen *ebiten.Image) {
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(float64(i.Pos.X), float64(i.Pos.Y))
+	screen.DrawImage(i.Image, op)
+}
@@ -1 +1,145 @@
 package components
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/Krud3/InteligenciaArtificial/src/input"
+	"github.com/Krud3/InteligenciaArtificial/src/visuals"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// Dropdown is a button that shows the selected option and opens the list of
+// options under it, over the other widgets. With the focus Enter, Space or
+// Down open it, the arrows move through the options, Enter picks one and
+// Escape closes it
+type Dropdown struct {
+	Rect      image.Rectangle
+	Options   []string
+	Selected  int
+	Color     color.RGBA
+	OnChange  func(index int)
+	open      bool
+	highlight int // Option under the keyboard while it's open
+}
+
+func (d *Dropdown) optionRect(index int) image.Rectangle {
+	y := d.Rect.Max.Y + index*listItemHeight
+	return image.Rect(d.Rect.Min.X, y, d.Rect.Max.X, y+listItemHeight)
+}
+
+// Overlaid is true while the options are shown
+func (d *Dropdown) Overlaid() bool {
+	return d.open
+}
+
+func (d *Dropdown) Bounds() image.Rectangle {
+	return d.Rect
+}
+
+func (d *Dropdown) CanFocus() bool {
+	return len(d.Options) > 0
+}
+
+func (d *Dropdown) setOpen(open bool) {
+	d.open = open
+	d.highlight = d.Selected
+}
+
+func (d *Dropdown) choose(index int) {
+	d.open = false
+	if index != d.Selected {
+		d.Selected = index
+		if d.OnChange != nil {
+			d.OnChange(index)
+		}
+	}
+}
+
+func (d *Dropdown) HandleKeys() bool {
+	if !d.open {
+		if input.Pressed(ebiten.KeyEnter, ebiten.KeySpace, ebiten.KeyArrowDown) {
+			d.setOpen(true)
+			return true
+		}
+		return false
+	}
+	switch {
+	case input.Pressed(ebiten.KeyEscape):
+		d.open = false
+	case input.Repeated(ebiten.KeyArrowDown):
+		d.highlight = min(d.highlight+1, len(d.Options)-1)
+	case input.Repeated(ebiten.KeyArrowUp):
+		d.highlight = max(d.highlight-1, 0)
+	case input.Pressed(ebiten.KeyEnter, ebiten.KeySpace):
+		d.choose(d.highlight)
+	case input.Pressed(ebiten.KeyTab):
+		// Leaving with Tab closes it, the focus still moves
+		d.open = false
+		return false
+	default:
+		return false
+	}
+	return true
+}
+
+func (d *Dropdown) Update() bool {
+	if !d.open {
+		if input.Clicked(d.Rect) {
+			d.setOpen(true)
+			return true
+		}
+		return false
+	}
+	for i := range d.Options {
+		if input.Hovered(d.optionRect(i)) {
+			d.highlight = i
+		}
+	}
+	if !inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		// Nothing under the open options gets the hover or the wheel
+		return input.Hovered(d.optionRect(0).Union(d.optionRect(len(d.Options) - 1)))
+	}
+	// Any click closes it, only the ones on an option change the selection
+	d.open = false
+	for i := range d.Options {
+		if input.Hovered(d.optionRect(i)) {
+			d.choose(i)
+			return true
+		}
+	}
+	return input.Hovered(d.Rect)
+}
+
+func (d *Dropdown) Draw(screen *ebiten.Image) {
+	fillRect(screen, d.Rect, d.Color)
+	fillRect(screen, d.Rect.Inset(5), visuals.Darken(d.Color, 15))
+	label := ""
+	if d.Selected >= 0 && d.Selected < len(d.Options) {
+		label = d.Options[d.Selected]
+	}
+	printCentered(screen, label, d.Rect)
+	arrow := "v"
+	if d.open {
+		arrow = "^"
+	}
+	ebitenutil.DebugPrintAt(screen, arrow, d.Rect.Max.X-20, d.Rect.Min.Y+(d.Rect.Dy()-lineHeight)/2)
+}
+
+func (d *Dropdown) DrawOverlay(screen *ebiten.Image) {
+	for i, option := range d.Options {
+		rect := d.optionRect(i)
+		clr := visuals.PanelColor
+		if i == d.highlight {
+			clr = visuals.HoverColor
+		}
+		fillRect(screen, rect, clr)
+		text := option
+		if i == d.Selected {
+			text = "> " + text
+		}
+		ebitenutil.DebugPrintAt(screen, clip(text, rect.Dx()-10), rect.Min.X+10, rect.Min.Y+2)
+	}
+}
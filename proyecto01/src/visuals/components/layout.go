@@ -0,0 +1,34 @@
+package components
+
+import "image"
+
+// Stack hands out rectangles one under the other, so a column of widgets is
+// laid out without computing each position by hand
+type Stack struct {
+	X, Y  int
+	Width int
+	Gap   int // Space left between two rectangles
+}
+
+// Next returns the rectangle of the next widget, height pixels tall
+func (s *Stack) Next(height int) image.Rectangle {
+	rect := image.Rect(s.X, s.Y, s.X+s.Width, s.Y+height)
+	s.Y += height + s.Gap
+	return rect
+}
+
+// Skip leaves height pixels empty
+func (s *Stack) Skip(height int) {
+	s.Y += height
+}
+
+// Columns splits rect in n columns of the same width, gap pixels apart
+func Columns(rect image.Rectangle, n, gap int) []image.Rectangle {
+	width := (rect.Dx() - gap*(n-1)) / n
+	columns := make([]image.Rectangle, n)
+	for i := range columns {
+		x := rect.Min.X + i*(width+gap)
+		columns[i] = image.Rect(x, rect.Min.Y, x+width, rect.Max.Y)
+	}
+	return columns
+}
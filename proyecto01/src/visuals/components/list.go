@@ -0,0 +1,153 @@
+package components
+
+import (
+	"image"
+
+	"github.com/Krud3/InteligenciaArtificial/src/input"
+	"github.com/Krud3/InteligenciaArtificial/src/visuals"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+const listItemHeight = 20
+
+// List is a scrollable column of items, one of them can be selected. Items
+// and Selected can be changed from outside between frames. With the focus
+// the arrows move the selection, Enter activates it and Space toggles it
+type List struct {
+	Rect     image.Rectangle
+	Items    []string
+	Selected int // -1 if none
+	OnSelect func(index int)
+	// Enter on the selected item, nil to ignore it
+	OnActivate func(index int)
+	// Ctrl+click or Space calls OnToggle instead of selecting, nil to ignore it
+	OnToggle func(index int)
+	// Marked items get a "+ " in front, nil if none is
+	Marked func(index int) bool
+	scroll int // Index of the first visible item
+}
+
+func (l *List) Bounds() image.Rectangle {
+	return l.Rect
+}
+
+func (l *List) CanFocus() bool {
+	return len(l.Items) > 0
+}
+
+func (l *List) valid(index int) bool {
+	return index >= 0 && index < len(l.Items)
+}
+
+func (l *List) selectItem(index int) {
+	l.Selected = index
+	l.ScrollTo(index)
+	if l.OnSelect != nil {
+		l.OnSelect(index)
+	}
+}
+
+func (l *List) HandleKeys() bool {
+	switch {
+	case input.Repeated(ebiten.KeyArrowDown):
+		// Nothing selected starts from the top
+		l.selectItem(min(l.Selected+1, len(l.Items)-1))
+	case input.Repeated(ebiten.KeyArrowUp):
+		l.selectItem(max(l.Selected-1, 0))
+	case input.Pressed(ebiten.KeyHome):
+		l.selectItem(0)
+	case input.Pressed(ebiten.KeyEnd):
+		l.selectItem(len(l.Items) - 1)
+	case input.Pressed(ebiten.KeyEnter) && l.valid(l.Selected) && l.OnActivate != nil:
+		l.OnActivate(l.Selected)
+	case input.Pressed(ebiten.KeySpace) && l.valid(l.Selected) && l.OnToggle != nil:
+		l.OnToggle(l.Selected)
+	default:
+		return false
+	}
+	return true
+}
+
+// visibleItems is how many items fit in the list
+func (l *List) visibleItems() int {
+	return max(1, l.Rect.Dy()/listItemHeight)
+}
+
+func (l *List) itemRect(index int) image.Rectangle {
+	y := l.Rect.Min.Y + (index-l.scroll)*listItemHeight
+	return image.Rect(l.Rect.Min.X, y, l.Rect.Max.X, y+listItemHeight)
+}
+
+// ScrollTo moves the list so the item is visible
+func (l *List) ScrollTo(index int) {
+	if index < l.scroll {
+		l.scroll = index
+	}
+	if index >= l.scroll+l.visibleItems() {
+		l.scroll = index - l.visibleItems() + 1
+	}
+	l.clampScroll()
+}
+
+func (l *List) clampScroll() {
+	l.scroll = max(0, min(l.scroll, len(l.Items)-l.visibleItems()))
+}
+
+func (l *List) Update() bool {
+	if !input.Hovered(l.Rect) {
+		return false
+	}
+	if _, dy := ebiten.Wheel(); dy != 0 {
+		if dy > 0 {
+			l.scroll--
+		} else {
+			l.scroll++
+		}
+		l.clampScroll()
+	}
+	if !input.Clicked(l.Rect) {
+		return false
+	}
+	index := l.scroll + l.cursorRow()
+	if !l.valid(index) {
+		return true
+	}
+	if input.Control() && l.OnToggle != nil {
+		l.OnToggle(index)
+		return true
+	}
+	l.selectItem(index)
+	return true
+}
+
+// cursorRow is the visible row under the cursor
+func (l *List) cursorRow() int {
+	_, y := ebiten.CursorPosition()
+	return (y - l.Rect.Min.Y) / listItemHeight
+}
+
+func (l *List) Draw(screen *ebiten.Image) {
+	fillRect(screen, l.Rect, visuals.PanelColor)
+	l.clampScroll()
+	for i := l.scroll; i < len(l.Items) && i < l.scroll+l.visibleItems(); i++ {
+		rect := l.itemRect(i)
+		if input.Hovered(rect) {
+			fillRect(screen, rect, visuals.HoverColor)
+		}
+		text := l.Items[i]
+		if i == l.Selected {
+			text = "> " + text
+		}
+		if l.Marked != nil && l.Marked(i) {
+			text = "+ " + text
+		}
+		ebitenutil.DebugPrintAt(screen, clip(text, rect.Dx()-10), rect.Min.X+10, rect.Min.Y+2)
+	}
+	// A bar on the right shows where the visible items are
+	if len(l.Items) > l.visibleItems() {
+		height := l.Rect.Dy() * l.visibleItems() / len(l.Items)
+		y := l.Rect.Min.Y + l.Rect.Dy()*l.scroll/len(l.Items)
+		fillRect(screen, image.Rect(l.Rect.Max.X-4, y, l.Rect.Max.X, y+height), visuals.HeaderColor)
+	}
+}
@@ -1 +1,175 @@
+// Package components is a small set of ebiten widgets: buttons, labels,
+// scrollable lists, dropdowns and sortable tables, laid out with Stack and
+// grouped in a Playground
 package components
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Krud3/InteligenciaArtificial/src/input"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Size of a character of the debug font used by every widget
+const (
+	charWidth  = 6
+	lineHeight = 16
+)
+
+// Widget is anything a Playground can update and draw. Update returns true
+// when the widget used the input of this frame, so the ones under it don't
+type Widget interface {
+	Update() bool
+	Draw(screen *ebiten.Image)
+}
+
+// Focusable widgets can be reached with Tab and used with the keyboard
+type Focusable interface {
+	Widget
+	Bounds() image.Rectangle
+	// CanFocus is false for the widgets that are disabled right now
+	CanFocus() bool
+	// HandleKeys gets the keys while the widget has the focus, before anything else
+	HandleKeys() bool
+}
+
+// overlay is a widget that draws something over the rest, like an open
+// dropdown, and takes the input before them
+type overlay interface {
+	Widget
+	Overlaid() bool
+	DrawOverlay(screen *ebiten.Image)
+}
+
+var focusColor = color.RGBA{255, 220, 0, 255}
+
+// Playground is a group of widgets, the last added is the one on top. Tab
+// and Shift+Tab move the focus between them and Escape drops it
+type Playground struct {
+	widgets []Widget
+	focused Focusable
+}
+
+func (p *Playground) Add(widgets ...Widget) {
+	p.widgets = append(p.widgets, widgets...)
+}
+
+// Focus gives the focus to a widget, nil to leave none focused
+func (p *Playground) Focus(widget Focusable) {
+	p.focused = widget
+}
+
+func (p *Playground) Focused() Focusable {
+	return p.focused
+}
+
+// moveFocus focuses the next widget that can take it, or the previous one
+func (p *Playground) moveFocus(step int) {
+	var candidates []Focusable
+	current := -1
+	for _, widget := range p.widgets {
+		if f, ok := widget.(Focusable); ok && f.CanFocus() {
+			if f == p.focused {
+				current = len(candidates)
+			}
+			candidates = append(candidates, f)
+		}
+	}
+	if len(candidates) == 0 {
+		p.focused = nil
+		return
+	}
+	if current < 0 && step < 0 {
+		current = 0
+	}
+	p.focused = candidates[((current+step)%len(candidates)+len(candidates))%len(candidates)]
+}
+
+// Update gives the input to the widgets until one uses it: first the open
+// overlays, then the focused widget, then the focus keys and at last the
+// mouse, from the top down. It returns true if the input was used
+func (p *Playground) Update() bool {
+	if p.focused != nil && !p.focused.CanFocus() {
+		p.focused = nil
+	}
+	for _, widget := range p.widgets {
+		if o, ok := widget.(overlay); ok && o.Overlaid() {
+			if o.Update() {
+				return true
+			}
+		}
+	}
+	if p.focused != nil && p.focused.HandleKeys() {
+		return true
+	}
+	switch {
+	case input.Pressed(ebiten.KeyTab):
+		if input.Shift() {
+			p.moveFocus(-1)
+		} else {
+			p.moveFocus(1)
+		}
+		return true
+	case input.Pressed(ebiten.KeyEscape) && p.focused != nil:
+		p.focused = nil
+		return true
+	}
+	for i := len(p.widgets) - 1; i >= 0; i-- {
+		if o, ok := p.widgets[i].(overlay); ok && o.Overlaid() {
+			continue
+		}
+		if p.widgets[i].Update() {
+			// A click also moves the focus to the widget
+			if f, ok := p.widgets[i].(Focusable); ok && f.CanFocus() {
+				p.focused = f
+			}
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Playground) Draw(screen *ebiten.Image) {
+	for _, widget := range p.widgets {
+		widget.Draw(screen)
+	}
+	if p.focused != nil {
+		bounds := p.focused.Bounds()
+		vector.StrokeRect(screen, float32(bounds.Min.X)-2, float32(bounds.Min.Y)-2, float32(bounds.Dx())+4, float32(bounds.Dy())+4, 2, focusColor, false)
+	}
+	for _, widget := range p.widgets {
+		if o, ok := widget.(overlay); ok && o.Overlaid() {
+			o.DrawOverlay(screen)
+		}
+	}
+}
+
+func fillRect(screen *ebiten.Image, rect image.Rectangle, clr color.Color) {
+	ebitenutil.DrawRect(screen, float64(rect.Min.X), float64(rect.Min.Y), float64(rect.Dx()), float64(rect.Dy()), clr)
+}
+
+// printCentered writes text, of one or more lines, in the middle of rect
+func printCentered(screen *ebiten.Image, text string, rect image.Rectangle) {
+	lines := strings.Split(text, "\n")
+	width := 0
+	for _, line := range lines {
+		width = max(width, utf8.RuneCountInString(line)*charWidth)
+	}
+	x := rect.Min.X + (rect.Dx()-width)/2
+	y := rect.Min.Y + (rect.Dy()-len(lines)*lineHeight)/2
+	ebitenutil.DebugPrintAt(screen, text, x, y)
+}
+
+// clip cuts text so it fits in width pixels, counting letters and not bytes
+// so the accents aren't cut in half
+func clip(text string, width int) string {
+	if chars := width / charWidth; utf8.RuneCountInString(text) > chars && chars >= 0 {
+		return string([]rune(text)[:chars])
+	}
+	return text
+}
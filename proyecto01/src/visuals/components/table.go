@@ -1 +1,149 @@
 package components
+
+import (
+	"image"
+	"sort"
+	"strconv"
+
+	"github.com/Krud3/InteligenciaArtificial/src/input"
+	"github.com/Krud3/InteligenciaArtificial/src/visuals"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+const tableRowHeight = 25
+
+// Column of a Table, Width in pixels
+type Column struct {
+	Title string
+	Width int
+}
+
+// TableRow is a row of cells, Highlight paints it green like the best
+// result of a comparison
+type TableRow struct {
+	Cells     []string
+	Highlight bool
+}
+
+// Table shows rows under a header. Clicking a header sorts the rows by that
+// column, clicking it again reverses the order
+type Table struct {
+	Pos        image.Point
+	Columns    []Column
+	Rows       []TableRow
+	Sortable   bool
+	sortColumn int // -1 keeps the order of Rows
+	descending bool
+	sorted     bool
+}
+
+// NewTable returns a table that keeps the rows in the order they're given
+// until a header is clicked
+func NewTable(pos image.Point, columns ...Column) *Table {
+	return &Table{Pos: pos, Columns: columns, sortColumn: -1}
+}
+
+// SetRows replaces the rows, keeping the current sort
+func (t *Table) SetRows(rows []TableRow) {
+	t.Rows = rows
+	t.sorted = false
+}
+
+func (t *Table) width() int {
+	width := 0
+	for _, column := range t.Columns {
+		width += column.Width
+	}
+	return width
+}
+
+func (t *Table) headerRect(column int) image.Rectangle {
+	x := t.Pos.X
+	for _, c := range t.Columns[:column] {
+		x += c.Width
+	}
+	return image.Rect(x, t.Pos.Y, x+t.Columns[column].Width, t.Pos.Y+tableRowHeight)
+}
+
+func (t *Table) Update() bool {
+	if !t.Sortable {
+		return false
+	}
+	for i := range t.Columns {
+		if input.Clicked(t.headerRect(i)) {
+			if t.sortColumn == i {
+				t.descending = !t.descending
+			} else {
+				t.sortColumn, t.descending = i, false
+			}
+			t.sorted = false
+			return true
+		}
+	}
+	return false
+}
+
+// sortRows orders the rows by the sort column, numbers by their value and
+// the rest alphabetically
+func (t *Table) sortRows() {
+	if t.sorted || t.sortColumn < 0 {
+		return
+	}
+	column := t.sortColumn
+	cell := func(row TableRow) string {
+		if column < len(row.Cells) {
+			return row.Cells[column]
+		}
+		return ""
+	}
+	sort.SliceStable(t.Rows, func(i, j int) bool {
+		a, b := cell(t.Rows[i]), cell(t.Rows[j])
+		if t.descending {
+			a, b = b, a
+		}
+		numberA, errA := strconv.ParseFloat(a, 64)
+		numberB, errB := strconv.ParseFloat(b, 64)
+		if errA == nil && errB == nil {
+			return numberA < numberB
+		}
+		return a < b
+	})
+	t.sorted = true
+}
+
+func (t *Table) Draw(screen *ebiten.Image) {
+	t.sortRows()
+	fillRect(screen, image.Rect(t.Pos.X, t.Pos.Y, t.Pos.X+t.width(), t.Pos.Y+tableRowHeight), visuals.HeaderColor)
+	for i, column := range t.Columns {
+		title := column.Title
+		if i == t.sortColumn {
+			if t.descending {
+				title += " v"
+			} else {
+				title += " ^"
+			}
+		}
+		rect := t.headerRect(i)
+		ebitenutil.DebugPrintAt(screen, clip(title, rect.Dx()-4), rect.Min.X+4, rect.Min.Y+4)
+	}
+
+	for i, row := range t.Rows {
+		y := t.Pos.Y + (i+1)*tableRowHeight
+		if row.Highlight {
+			fillRect(screen, image.Rect(t.Pos.X, y, t.Pos.X+t.width(), y+tableRowHeight-3), visuals.HighlightColor)
+		}
+		for j, text := range row.Cells {
+			if j >= len(t.Columns) {
+				break
+			}
+			rect := t.headerRect(j)
+			width := rect.Dx()
+			if j == len(row.Cells)-1 {
+				// The last cell of a short row, like an error, takes the rest of it
+				width = t.Pos.X + t.width() - rect.Min.X
+			}
+			ebitenutil.DebugPrintAt(screen, clip(text, width-4), rect.Min.X+4, y+4)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package visuals
+
+import "image/color"
+
+// Theme is a set of colours for the background and the widgets, the buttons
+// keep their own colours in every theme
+type Theme struct {
+	Name                            string
+	Background                      color.RGBA
+	Panel, Header, Highlight, Hover color.RGBA
+}
+
+// Themes that can be picked, the first one is the default
+var Themes = []Theme{
+	{Name: "classic", Background: color.RGBA{0, 0, 0, 255}, Panel: PanelColor, Header: HeaderColor, Highlight: HighlightColor, Hover: HoverColor},
+	{Name: "night", Background: color.RGBA{20, 24, 36, 255}, Panel: color.RGBA{45, 55, 80, 255}, Header: color.RGBA{30, 36, 56, 255}, Highlight: color.RGBA{20, 110, 90, 255}, Hover: color.RGBA{70, 85, 120, 255}},
+}
+
+// Background fills the screen behind everything
+var Background = Themes[0].Background
+
+var currentTheme = 0
+
+// SetTheme changes the colours to the ones of a theme, an unknown name is
+// ignored and returns false
+func SetTheme(name string) bool {
+	for i, theme := range Themes {
+		if theme.Name == name {
+			currentTheme = i
+			Background, PanelColor, HeaderColor, HighlightColor, HoverColor = theme.Background, theme.Panel, theme.Header, theme.Highlight, theme.Hover
+			return true
+		}
+	}
+	return false
+}
+
+// ThemeName is the name of the theme in use
+func ThemeName() string {
+	return Themes[currentTheme].Name
+}
+
+// NextTheme switches to the theme after the current one
+func NextTheme() {
+	SetTheme(Themes[(currentTheme+1)%len(Themes)].Name)
+}